@@ -0,0 +1,16 @@
+// Package docs embeds the OpenAPI document of the API and the page that
+// renders it.
+package docs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 document describing every route.
+//
+//go:embed openapi.json
+var OpenAPI []byte
+
+// Viewer is a self-contained HTML page that loads openapi.json from the same
+// directory and renders it.
+//
+//go:embed index.html
+var Viewer []byte
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"digitalrepublic/pkg/apikey"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	missingAPIKeyError     = "chave de API ausente, envie o cabeçalho X-API-Key"
+	rateLimitedError       = "limite de requisições da chave de API atingido, tente novamente mais tarde"
+	invalidAdminTokenError = "token de administração invalido"
+)
+
+const (
+	apiKeyHeader   = "X-API-Key"
+	apiKeyLocal    = "apikey"
+	rateLimitLimit = "X-RateLimit-Limit"
+	rateLimitLeft  = "X-RateLimit-Remaining"
+	rateLimitReset = "X-RateLimit-Reset"
+)
+
+// RequireAPIKey authenticates the request with the key sent in X-API-Key, or
+// as a bearer token, and counts it against the limits of that key. Every
+// authenticated response carries the X-RateLimit-* headers, Reset being a
+// Unix time in seconds.
+func RequireAPIKey(store apikey.Store, limiter *apikey.Limiter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		secret := c.Get(apiKeyHeader)
+		if secret == "" {
+			secret = bearerToken(c)
+		}
+		if secret == "" {
+			return NewProblem(http.StatusUnauthorized, missingAPIKeyError)
+		}
+
+		key, err := apikey.Authenticate(store, secret)
+		if errors.Is(err, apikey.ErrInvalidKey) || errors.Is(err, apikey.ErrRevoked) {
+			return NewProblem(http.StatusUnauthorized, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+		c.Locals(apiKeyLocal, key.ID)
+
+		decision := limiter.Allow(key)
+		c.Set(rateLimitLimit, strconv.Itoa(decision.Limit))
+		c.Set(rateLimitLeft, strconv.Itoa(decision.Remaining))
+		c.Set(rateLimitReset, strconv.FormatInt(decision.Reset.Unix(), 10))
+		if !decision.Allowed {
+			retry := decision.RetryAfter(time.Now())
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+			return NewProblem(http.StatusTooManyRequests, rateLimitedError)
+		}
+
+		return c.Next()
+
+	}
+}
+
+// APIKeyID returns the ID of the key that authenticated the request, or an
+// empty string when keys are not required.
+func APIKeyID(c *fiber.Ctx) string {
+	id, _ := c.Locals(apiKeyLocal).(string)
+	return id
+}
+
+// RequireAdminToken lets through only the requests sending the admin token as
+// a bearer token.
+func RequireAdminToken(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		sent := bearerToken(c)
+		if sent == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+			return NewProblem(http.StatusUnauthorized, invalidAdminTokenError)
+		}
+		return c.Next()
+
+	}
+}
+
+func bearerToken(c *fiber.Ctx) string {
+	scheme, token, ok := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// keyResponse is a key as shown by the admin routes, without its hash.
+type keyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	RateLimit  int        `json:"rate_limit"`
+	DailyQuota int        `json:"daily_quota"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func newKeyResponse(key apikey.Key) keyResponse {
+	return keyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		RateLimit:  key.RateLimit,
+		DailyQuota: key.DailyQuota,
+		CreatedAt:  key.CreatedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+// IssueKey creates a key from {"name", "rate_limit", "daily_quota"} and
+// answers with its secret, which is not shown again. rate_limit defaults to
+// defaultRateLimit and a daily_quota of 0 is unlimited.
+func IssueKey(store apikey.Store, defaultRateLimit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		requestBody := struct {
+			Name       string `json:"name"`
+			RateLimit  *int   `json:"rate_limit"`
+			DailyQuota int    `json:"daily_quota"`
+		}{}
+		err := decodeBody(c, &requestBody)
+		if err != nil {
+			return err
+		}
+
+		rateLimit := defaultRateLimit
+		if requestBody.RateLimit != nil {
+			rateLimit = *requestBody.RateLimit
+		}
+
+		key, secret, err := apikey.Issue(store, requestBody.Name, rateLimit, requestBody.DailyQuota)
+		if err != nil {
+			return NewProblem(http.StatusBadRequest, err.Error())
+		}
+
+		Logger(c).Info("api key issued", "api_key", key.ID, "name", key.Name)
+		return c.Status(http.StatusCreated).JSON(&fiber.Map{
+			"key":    newKeyResponse(key),
+			"secret": secret,
+		})
+
+	}
+}
+
+func ListKeys(store apikey.Store) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		keys, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		response := make([]keyResponse, len(keys))
+		for i, key := range keys {
+			response[i] = newKeyResponse(key)
+		}
+		return c.JSON(response)
+
+	}
+}
+
+func RevokeKey(store apikey.Store) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		key, err := store.Revoke(c.Params("id"))
+		if errors.Is(err, apikey.ErrNotFound) {
+			return NewProblem(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+
+		Logger(c).Info("api key revoked", "api_key", key.ID)
+		return c.JSON(newKeyResponse(key))
+
+	}
+}
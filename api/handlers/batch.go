@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+type batchRequest struct {
+	Rooms []paint.BatchItem `json:"rooms"`
+}
+
+type batchResponse struct {
+	Results map[string]paint.BatchResult `json:"results"`
+}
+
+// Batch calculates many rooms in one request. Results are keyed by the id of
+// each room; with Accept: application/x-ndjson they are streamed one per line
+// as soon as each room is done instead.
+func Batch(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		var requestBody batchRequest
+
+		err := decodeBody(c, &requestBody)
+		if err != nil {
+			return err
+		}
+
+		ctx := requestContext(c)
+
+		if c.Accepts(fiber.MIMEApplicationJSON, ndjsonContentType) == ndjsonContentType {
+			// The status is sent before the stream starts, so the batch is
+			// checked here rather than by the service.
+			err = paint.ValidateBatch(requestBody.Rooms)
+			if err != nil {
+				return err
+			}
+
+			c.Set(fiber.HeaderContentType, ndjsonContentType)
+			c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+				// A failed write means the client went away, so the rooms
+				// left are skipped.
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				encoder := json.NewEncoder(w)
+				_ = services.Batch(ctx, requestBody.Rooms, func(result paint.BatchResult) {
+					if encoder.Encode(result) != nil || w.Flush() != nil {
+						cancel()
+					}
+				})
+			})
+			return nil
+		}
+
+		response := batchResponse{Results: make(map[string]paint.BatchResult, len(requestBody.Rooms))}
+		err = services.Batch(ctx, requestBody.Rooms, func(result paint.BatchResult) {
+			response.Results[result.ID] = result
+		})
+		if err != nil {
+			return err
+		}
+		return c.JSON(response)
+
+	}
+
+}
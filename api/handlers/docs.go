@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"digitalrepublic/api/docs"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OpenAPI serves the OpenAPI document of the API.
+func OpenAPI() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(docs.OpenAPI)
+	}
+}
+
+// Docs serves the page that renders the OpenAPI document.
+func Docs() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.Send(docs.Viewer)
+	}
+}
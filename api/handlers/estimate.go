@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"strconv"
+)
+
+const (
+	invalidRevisionError = "número de revisão invalido"
+)
+
+func CreateEstimate(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		var requestBody paint.CalculateRoomPaintInCansInput
+
+		err := decodeBody(c, &requestBody)
+		if err != nil {
+			return err
+		}
+
+		created, err := services.CreateEstimate(requestContext(c), requestBody)
+		if err != nil {
+			return err
+		}
+		return c.Status(http.StatusCreated).JSON(created)
+
+	}
+
+}
+
+func ListEstimates(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		estimates, err := services.ListEstimates(requestContext(c))
+		if err != nil {
+			return err
+		}
+		return c.JSON(estimates)
+
+	}
+
+}
+
+func GetEstimate(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		found, err := services.GetEstimate(requestContext(c), c.Params("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(found)
+
+	}
+
+}
+
+func UpdateEstimate(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		var requestBody paint.CalculateRoomPaintInCansInput
+
+		err := decodeBody(c, &requestBody)
+		if err != nil {
+			return err
+		}
+
+		updated, err := services.UpdateEstimate(requestContext(c), c.Params("id"), requestBody)
+		if err != nil {
+			return err
+		}
+		return c.JSON(updated)
+
+	}
+
+}
+
+func DeleteEstimate(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		err := services.DeleteEstimate(requestContext(c), c.Params("id"))
+		if err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+
+	}
+
+}
+
+func ListRevisions(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		revisions, err := services.ListRevisions(requestContext(c), c.Params("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(revisions)
+
+	}
+
+}
+
+func GetRevision(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		number, err := strconv.Atoi(c.Params("number"))
+		if err != nil {
+			return NewProblem(http.StatusBadRequest, invalidRevisionError)
+		}
+
+		_, revision, err := services.Revision(requestContext(c), c.Params("id"), number)
+		if err != nil {
+			return err
+		}
+		return c.JSON(revision)
+
+	}
+
+}
+
+// DiffRevisions compares the revisions given by the from and to query
+// parameters. By default it compares the latest revision with the previous one.
+func DiffRevisions(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		to, err := queryRevision(c, "to")
+		if err != nil {
+			return NewProblem(http.StatusBadRequest, invalidRevisionError)
+		}
+		from, err := queryRevision(c, "from")
+		if err != nil {
+			return NewProblem(http.StatusBadRequest, invalidRevisionError)
+		}
+
+		diff, err := services.DiffRevisions(requestContext(c), c.Params("id"), from, to)
+		if err != nil {
+			return err
+		}
+		return c.JSON(diff)
+
+	}
+
+}
+
+// findRevision returns the estimate with the revision given by the revision
+// query parameter, or with its latest revision when it is absent.
+func findRevision(c *fiber.Ctx, services *service.Service) (estimate.Estimate, estimate.Revision, error) {
+	number, err := queryRevision(c, "revision")
+	if err != nil {
+		return estimate.Estimate{}, estimate.Revision{}, NewProblem(http.StatusBadRequest, invalidRevisionError)
+	}
+	if number == nil {
+		return services.LatestRevision(requestContext(c), c.Params("id"))
+	}
+
+	return services.Revision(requestContext(c), c.Params("id"), *number)
+}
+
+// queryRevision reads a revision number from the query, nil when it is absent.
+func queryRevision(c *fiber.Ctx, key string) (*int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &number, nil
+}
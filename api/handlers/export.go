@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"bytes"
+	"digitalrepublic/pkg/exporter"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"strings"
+)
+
+// ExportEstimate sends the result of the latest revision of the estimate, or
+// the one given by the revision query parameter, in the requested format.
+func ExportEstimate(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		found, revision, err := findRevision(c, services)
+		if err != nil {
+			return err
+		}
+
+		return sendResult(c, revision.Result, fmt.Sprintf("orcamento-%s-r%d", found.ID, revision.Number))
+
+	}
+
+}
+
+// sendResult writes the result in the format given by the format query
+// parameter or, without it, the first one accepted by the Accept header.
+// JSON is used when the client accepts any format.
+func sendResult(c *fiber.Ctx, result paint.CalculateRoomPaintInCansOutput, filename string) error {
+	format := strings.ToLower(strings.TrimSpace(c.Query("format")))
+	if format == "" {
+		types := exporter.ContentTypes()
+		offers := make([]string, 0, len(exporter.Formats))
+		for _, name := range exporter.Formats {
+			e, _ := exporter.New(name)
+			offers = append(offers, e.ContentType())
+		}
+		format = types[c.Accepts(offers...)]
+	}
+	if format == "" {
+		format = exporter.FormatJSON
+	}
+
+	e, err := exporter.New(format)
+	if err != nil {
+		return NewProblem(http.StatusBadRequest, err.Error())
+	}
+	if format == exporter.FormatJSON {
+		return c.JSON(result)
+	}
+
+	var out bytes.Buffer
+	err = e.Export(&out, result)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, e.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, filename, e.Extension()))
+	return c.Send(out.Bytes())
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"digitalrepublic/pkg/buildinfo"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+)
+
+// Healthz answers as long as the process is serving requests.
+func Healthz() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(&fiber.Map{
+			"status": "ok",
+		})
+	}
+}
+
+// Readyz answers 503 while ready reports an error, so the orchestrator only
+// sends traffic once everything the API depends on is loaded.
+func Readyz(ready func() error) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		err := ready()
+		if err != nil {
+			return NewProblem(http.StatusServiceUnavailable, err.Error())
+		}
+		return c.JSON(&fiber.Map{
+			"status": "ready",
+		})
+
+	}
+}
+
+func Version() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(buildinfo.Get())
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bytes"
+	"digitalrepublic/pkg/importer"
+	"digitalrepublic/pkg/metrics"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	unsupportedFileError = "formato de arquivo não suportado: envie um arquivo CSV ou XLSX"
+	invalidImportError   = "o arquivo possui linhas invalidas, confira os campos e tente novamente"
+)
+
+type importedRoom struct {
+	importer.Room
+	Result *paint.CalculateRoomPaintInCansOutput `json:"result"`
+}
+
+// ImportWalls reads a wall list exported from a spreadsheet, either uploaded
+// as the "file" field of a multipart form or sent as the raw body, and returns
+// each room ready to be calculated or saved as an estimate.
+func ImportWalls(services *service.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		data, format, err := readImportFile(c)
+		if err != nil {
+			return NewProblem(http.StatusBadRequest, err.Error())
+		}
+
+		// The walls are checked as they are read; only the rooms calculated
+		// below count in the metrics.
+		ctx := metrics.Unrecorded(requestContext(c))
+
+		var rooms []importer.Room
+		switch format {
+		case "csv":
+			rooms, err = importer.ReadCSV(ctx, bytes.NewReader(data), services.Calculate)
+		case "xlsx":
+			rooms, err = importer.ReadXLSX(ctx, bytes.NewReader(data), int64(len(data)), services.Calculate)
+		default:
+			err = errors.New(unsupportedFileError)
+		}
+
+		var cellErrors importer.Errors
+		if errors.As(err, &cellErrors) {
+			return newImportProblem(cellErrors)
+		}
+		if err != nil {
+			return NewProblem(http.StatusBadRequest, err.Error())
+		}
+
+		result := make([]importedRoom, 0, len(rooms))
+		for _, room := range rooms {
+			output, err := services.Calculate(requestContext(c), room.Input)
+			if err != nil {
+				return problemFrom(err)
+			}
+			result = append(result, importedRoom{Room: room, Result: output})
+		}
+
+		return c.JSON(&fiber.Map{
+			"rooms": result,
+		})
+
+	}
+
+}
+
+// readImportFile returns the uploaded file and its format, taken from the
+// file extension or, for a raw body, from the Content-Type header.
+func readImportFile(c *fiber.Ctx) ([]byte, string, error) {
+
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", err
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), "."), nil
+	}
+
+	contentType := c.Get(fiber.HeaderContentType)
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return c.Body(), "csv", nil
+	case strings.HasPrefix(contentType, xlsxContentType):
+		return c.Body(), "xlsx", nil
+	}
+
+	return nil, "", errors.New(unsupportedFileError)
+}
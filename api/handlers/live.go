@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"digitalrepublic/pkg/decoder"
+	"digitalrepublic/pkg/live"
+	"digitalrepublic/pkg/metrics"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"log/slog"
+	"time"
+)
+
+const upgradeRequiredError = "esta rota aceita apenas conexões WebSocket"
+
+const (
+	liveIdleTimeout  = 10 * time.Minute
+	liveMessageLimit = 64 * 1024
+)
+
+const liveContextLocal = "livecontext"
+
+// liveMessage is an edit sent by the client. Seq is echoed in the state sent
+// back, so the client can tell which edit it answers.
+type liveMessage struct {
+	Seq int64 `json:"seq"`
+	live.Edit
+}
+
+// liveState is sent when the connection opens and after every message: the
+// room as edited so far, whether it can be calculated and either its result
+// or why not. EditErrors is set when the message itself was rejected, leaving
+// the room unchanged.
+type liveState struct {
+	Seq        int64                                 `json:"seq"`
+	Room       paint.CalculateRoomPaintInCansInput   `json:"room"`
+	Valid      bool                                  `json:"valid"`
+	Result     *paint.CalculateRoomPaintInCansOutput `json:"result,omitempty"`
+	Errors     []ProblemError                        `json:"errors,omitempty"`
+	EditErrors []ProblemError                        `json:"edit_errors,omitempty"`
+}
+
+// LiveRoom keeps a room for the lifetime of a WebSocket connection. Clients
+// send edits to it and get the recalculated room back after each one, so
+// recalculating on every keystroke costs one request instead of one per
+// keystroke.
+func LiveRoom(services *service.Service) fiber.Handler {
+	upgrade := websocket.New(func(conn *websocket.Conn) {
+
+		logger, ok := conn.Locals(loggerLocal).(*slog.Logger)
+		if !ok {
+			logger = slog.Default()
+		}
+		ctx, ok := conn.Locals(liveContextLocal).(context.Context)
+		if !ok {
+			ctx = context.Background()
+		}
+
+		// Closing the connection ends the read below once the server
+		// stops, which does not wait for hijacked connections.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		ws := conn.Conn
+		stop := context.AfterFunc(ctx, func() { _ = ws.Close() })
+		defer stop()
+
+		session := live.NewSession()
+		conn.SetReadLimit(liveMessageLimit)
+
+		state := liveRoomState(ctx, services, session)
+		for {
+			err := conn.WriteJSON(state)
+			if err != nil {
+				logger.Debug("live session closed", "error", err)
+				return
+			}
+
+			_ = conn.SetReadDeadline(time.Now().Add(liveIdleTimeout))
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				logger.Debug("live session closed", "error", err)
+				return
+			}
+
+			var message liveMessage
+			err = decoder.Strict(data, &message)
+			if err == nil {
+				err = session.Apply(message.Edit)
+			}
+
+			state = liveRoomState(ctx, services, session)
+			state.Seq = message.Seq
+			state.EditErrors = problemErrors(err)
+		}
+
+	})
+
+	return func(c *fiber.Ctx) error {
+
+		if !websocket.IsWebSocketUpgrade(c) {
+			return NewProblem(fiber.StatusUpgradeRequired, upgradeRequiredError)
+		}
+
+		// The rooms of a session are drafts recalculated on every keystroke,
+		// so they are left out of the calculation metrics.
+		c.Locals(liveContextLocal, metrics.Unrecorded(requestContext(c)))
+		return upgrade(c)
+
+	}
+}
+
+func liveRoomState(ctx context.Context, services *service.Service, session *live.Session) liveState {
+	state := liveState{Room: session.Room()}
+
+	result, err := services.Calculate(ctx, state.Room)
+	if err != nil {
+		state.Errors = problemErrors(err)
+		return state
+	}
+
+	state.Valid, state.Result = true, result
+	return state
+}
+
+// problemErrors lists the offending fields of err the way problems do.
+func problemErrors(err error) []ProblemError {
+	if err == nil {
+		return nil
+	}
+
+	var fields decoder.Errors
+	if errors.As(err, &fields) {
+		return newBodyProblem(fields).Errors
+	}
+	if problem := problemFrom(err); len(problem.Errors) > 0 {
+		return problem.Errors
+	}
+	return []ProblemError{{Detail: problemFrom(err).Detail}}
+}
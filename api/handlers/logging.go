@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"digitalrepublic/pkg/paint"
+	"encoding/hex"
+	"github.com/gofiber/fiber/v2"
+	"log/slog"
+	"regexp"
+	"time"
+)
+
+const (
+	requestIDLocal = "requestid"
+	loggerLocal    = "logger"
+)
+
+// validRequestID limits the request IDs accepted from clients, so a caller
+// cannot flood the logs or forge other fields through the header.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestLogger gives every request an ID, taken from the X-Request-ID header
+// when the client sends a valid one, echoes it in the response and logs the
+// request once it is done. Handlers log through Logger so that every line
+// carries the ID.
+func RequestLogger(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		id := c.Get(fiber.HeaderXRequestID)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(fiber.HeaderXRequestID, id)
+		c.Locals(requestIDLocal, id)
+
+		requestLogger := logger.With("request_id", id)
+		c.Locals(loggerLocal, requestLogger)
+
+		start := time.Now()
+		err := c.Next()
+
+		// Write the error here rather than after the chain returns, so the
+		// line logs the status actually sent.
+		if err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		level := slog.LevelInfo
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []any{
+			"method", c.Method(),
+			"path", c.Path(),
+			"route", c.Route().Path,
+			"status", status,
+			"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"ip", c.IP(),
+		}
+		if key := APIKeyID(c); key != "" {
+			attrs = append(attrs, "api_key", key)
+		}
+		if err != nil {
+			attrs = append(attrs, "error", err)
+		}
+		requestLogger.Log(c.UserContext(), level, "request", attrs...)
+		return nil
+
+	}
+}
+
+// RequestID returns the ID given to the request by RequestLogger.
+func RequestID(c *fiber.Ctx) string {
+	id, _ := c.Locals(requestIDLocal).(string)
+	return id
+}
+
+// Logger returns the logger of the request, which adds its ID to every line.
+func Logger(c *fiber.Ctx) *slog.Logger {
+	if logger, ok := c.Locals(loggerLocal).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// requestContext is the context handed to the service, logging through the
+// logger of the request.
+func requestContext(c *fiber.Ctx) context.Context {
+	return paint.ContextWithLogger(c.UserContext(), Logger(c))
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"bytes"
+	"digitalrepublic/pkg/metrics"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+	"time"
+)
+
+// RecordRequests counts every request and its latency by route pattern, so
+// that /estimates/:id is one series however many estimates there are.
+func RecordRequests(m *metrics.Metrics) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = problemFrom(err).Status
+		}
+
+		route, method, code := c.Route().Path, c.Method(), strconv.Itoa(status)
+		m.Requests.Inc(route, method, code)
+		m.Latency.Observe(time.Since(start).Seconds(), route, method, code)
+		return err
+
+	}
+}
+
+// Metrics exposes the metrics in the Prometheus text format.
+func Metrics(m *metrics.Metrics) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		var out bytes.Buffer
+		err := m.Registry.WriteText(&out)
+		if err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentType, metrics.ContentType)
+		return c.Send(out.Bytes())
+
+	}
+}
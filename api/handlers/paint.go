@@ -2,32 +2,30 @@ package handlers
 
 import (
 	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
 	"github.com/gofiber/fiber/v2"
-	"net/http"
 )
 
-func PaintSizes() fiber.Handler {
+const (
+	invalidBodyError = "Valores dos campos invalidos, confira os campos e tente novamente"
+)
+
+func PaintSizes(services *service.Service) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 
 		var requestBody paint.CalculateRoomPaintInCansInput
 
-		err := c.BodyParser(&requestBody)
+		err := decodeBody(c, &requestBody)
 		if err != nil {
-			c.Status(http.StatusBadRequest)
-			return c.JSON(&fiber.Map{
-				"Error": "Valores dos campos invalidos, confira os campos e tente novamente",
-			})
+			return err
 		}
 
-		interactor := paint.NewCalculateRoomPaintInCans()
-		result, err := interactor.Execute(requestBody)
+		result, err := services.Calculate(requestContext(c), requestBody)
 		if err != nil {
-			return c.Status(http.StatusBadRequest).JSON(&fiber.Map{
-				"Error": err.Error(),
-			})
+			return err
 
 		}
-		return c.JSON(result)
+		return sendResult(c, *result, "tinta")
 
 	}
 
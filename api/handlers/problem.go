@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"digitalrepublic/pkg/decoder"
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/importer"
+	"digitalrepublic/pkg/paint"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+)
+
+// ProblemContentType is the media type of the error responses (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// internalErrorDetail replaces the message of unexpected errors, which may
+// hold file paths or other internals; the error itself is logged with the
+// request ID given to the client.
+const internalErrorDetail = "erro interno do servidor, informe o request_id ao suporte"
+
+const (
+	validationProblemType   = "/problems/validation"
+	validationProblemTitle  = "Validation failed"
+	invalidBodyProblemType  = "/problems/invalid-body"
+	invalidBodyProblemTitle = "Invalid request body"
+)
+
+// Problem is the body of every error response. Handlers return it as an
+// error and ErrorHandler writes it, adding the instance and request ID.
+type Problem struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Status    int            `json:"status"`
+	Detail    string         `json:"detail,omitempty"`
+	Instance  string         `json:"instance,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+	Errors    []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError is one offending field: a JSON pointer into the request body
+// or, for imported spreadsheets, a row and column.
+type ProblemError struct {
+	Pointer string `json:"pointer,omitempty"`
+	Row     int    `json:"row,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Detail  string `json:"detail"`
+}
+
+func (p *Problem) Error() string {
+	return p.Detail
+}
+
+// NewProblem returns a problem of the generic type of status.
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+// newValidationProblem describes a validation error of the domain, pointing
+// at the field of the body that caused it.
+func newValidationProblem(err error) *Problem {
+	p := &Problem{Type: validationProblemType, Title: validationProblemTitle, Status: http.StatusBadRequest, Detail: err.Error()}
+	p.Errors = []ProblemError{{Pointer: paint.Pointer(err), Code: entities.ValidationCode(err), Detail: err.Error()}}
+	return p
+}
+
+// newImportProblem lists every invalid cell of an imported file.
+func newImportProblem(cells importer.Errors) *Problem {
+	p := &Problem{Type: validationProblemType, Title: validationProblemTitle, Status: http.StatusUnprocessableEntity, Detail: invalidImportError}
+	for _, cell := range cells {
+		p.Errors = append(p.Errors, ProblemError{Row: cell.Row, Column: cell.Column, Field: cell.Field, Code: entities.ValidationCode(cell.Err), Detail: cell.Err.Error()})
+	}
+	return p
+}
+
+// newBodyProblem lists every field of the body that could not be decoded.
+func newBodyProblem(fields decoder.Errors) *Problem {
+	p := &Problem{Type: invalidBodyProblemType, Title: invalidBodyProblemTitle, Status: http.StatusBadRequest, Detail: invalidBodyError}
+	for _, field := range fields {
+		p.Errors = append(p.Errors, ProblemError{Pointer: field.Pointer, Code: field.Code, Detail: field.Message})
+	}
+	return p
+}
+
+// decodeBody decodes the JSON body strictly into target, answering with every
+// unknown, missing or mistyped field when it does not match.
+func decodeBody(c *fiber.Ctx, target interface{}) error {
+	err := decoder.Strict(c.Body(), target)
+
+	var fields decoder.Errors
+	if errors.As(err, &fields) {
+		return newBodyProblem(fields)
+	}
+	if err != nil {
+		return NewProblem(http.StatusBadRequest, invalidBodyError)
+	}
+	return nil
+}
+
+// problemFrom turns any error returned by a handler into a problem. Errors it
+// does not know become a 500 without their message.
+func problemFrom(err error) *Problem {
+	var problem *Problem
+	if errors.As(err, &problem) {
+		copied := *problem
+		return &copied
+	}
+
+	var fiberError *fiber.Error
+	if errors.As(err, &fiberError) {
+		return NewProblem(fiberError.Code, fiberError.Message)
+	}
+
+	if entities.ValidationCode(err) != "" {
+		return newValidationProblem(err)
+	}
+
+	if errors.Is(err, estimate.ErrNotFound) || errors.Is(err, estimate.ErrRevisionNotFound) {
+		return NewProblem(http.StatusNotFound, err.Error())
+	}
+
+	return NewProblem(http.StatusInternalServerError, internalErrorDetail)
+}
+
+// ErrorHandler writes the errors returned by handlers and middlewares as
+// application/problem+json.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	problem := problemFrom(err)
+	problem.Instance = c.OriginalURL()
+	problem.RequestID = RequestID(c)
+
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+
+	c.Status(problem.Status)
+	c.Set(fiber.HeaderContentType, ProblemContentType)
+	return c.Send(body)
+}
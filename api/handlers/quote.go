@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"bytes"
+	"digitalrepublic/pkg/catalog"
+	"digitalrepublic/pkg/reporting"
+	"digitalrepublic/pkg/service"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+// EstimateQuote renders the latest revision of the estimate, or the one given
+// by the revision query parameter, as a PDF quote.
+func EstimateQuote(services *service.Service, prices catalog.Catalog) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		found, revision, err := findRevision(c, services)
+		if err != nil {
+			return err
+		}
+
+		quote := reporting.NewQuote(found, revision, prices, time.Now())
+
+		var pdf bytes.Buffer
+		err = reporting.RenderPDF(&pdf, quote)
+		if err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="orcamento-%s.pdf"`, quote.Number))
+		return c.Send(pdf.Bytes())
+
+	}
+
+}
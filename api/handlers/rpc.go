@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"digitalrepublic/pkg/decoder"
+	"digitalrepublic/pkg/jsonrpc"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+)
+
+// rpcCodeNotFound is the JSON-RPC error code of a missing estimate or
+// revision, in the range the specification leaves to servers.
+const rpcCodeNotFound = -32004
+
+type rpcEstimateID struct {
+	ID string `json:"id" required:"true"`
+}
+
+type rpcEstimateUpdate struct {
+	ID string `json:"id" required:"true"`
+	paint.CalculateRoomPaintInCansInput
+}
+
+type rpcRevision struct {
+	ID     string `json:"id" required:"true"`
+	Number *int   `json:"number"`
+}
+
+type rpcDiff struct {
+	ID   string `json:"id" required:"true"`
+	From *int   `json:"from"`
+	To   *int   `json:"to"`
+}
+
+// RPC answers JSON-RPC 2.0 requests with the operations of the REST routes,
+// through the same service, so both return the same results. Errors carry
+// the problem the REST route would answer as their data.
+func RPC(services *service.Service) fiber.Handler {
+	server := newRPCServer(services)
+
+	return func(c *fiber.Ctx) error {
+
+		response := server.Handle(requestContext(c), c.Body())
+		if response == nil {
+			return c.SendStatus(http.StatusNoContent)
+		}
+
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(response)
+
+	}
+}
+
+// newRPCServer registers the operations of services as JSON-RPC methods,
+// named after the interactor and the estimates they use. Params are passed
+// by name, as the bodies of the REST routes.
+func newRPCServer(services *service.Service) *jsonrpc.Server {
+	server := jsonrpc.NewServer(rpcError)
+
+	server.Register("CalculateRoomPaintInCans.Execute", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var room paint.CalculateRoomPaintInCansInput
+		if err := rpcParams(params, &room); err != nil {
+			return nil, err
+		}
+		return services.Calculate(ctx, room)
+	})
+	server.Register("CalculateRoomPaintInCans.Batch", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var batch batchRequest
+		if err := rpcParams(params, &batch); err != nil {
+			return nil, err
+		}
+		response := batchResponse{Results: make(map[string]paint.BatchResult, len(batch.Rooms))}
+		err := services.Batch(ctx, batch.Rooms, func(result paint.BatchResult) {
+			response.Results[result.ID] = result
+		})
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	})
+
+	server.Register("Estimates.Create", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var room paint.CalculateRoomPaintInCansInput
+		if err := rpcParams(params, &room); err != nil {
+			return nil, err
+		}
+		return services.CreateEstimate(ctx, room)
+	})
+	server.Register("Estimates.List", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		if err := rpcParams(params, &struct{}{}); err != nil {
+			return nil, err
+		}
+		return services.ListEstimates(ctx)
+	})
+	server.Register("Estimates.Get", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var target rpcEstimateID
+		if err := rpcParams(params, &target); err != nil {
+			return nil, err
+		}
+		return services.GetEstimate(ctx, target.ID)
+	})
+	server.Register("Estimates.Update", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var update rpcEstimateUpdate
+		if err := rpcParams(params, &update); err != nil {
+			return nil, err
+		}
+		return services.UpdateEstimate(ctx, update.ID, update.CalculateRoomPaintInCansInput)
+	})
+	server.Register("Estimates.Delete", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var target rpcEstimateID
+		if err := rpcParams(params, &target); err != nil {
+			return nil, err
+		}
+		return nil, services.DeleteEstimate(ctx, target.ID)
+	})
+	server.Register("Estimates.Revisions", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var target rpcEstimateID
+		if err := rpcParams(params, &target); err != nil {
+			return nil, err
+		}
+		return services.ListRevisions(ctx, target.ID)
+	})
+	server.Register("Estimates.Revision", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var target rpcRevision
+		if err := rpcParams(params, &target); err != nil {
+			return nil, err
+		}
+		if target.Number == nil {
+			_, revision, err := services.LatestRevision(ctx, target.ID)
+			return revision, err
+		}
+		_, revision, err := services.Revision(ctx, target.ID, *target.Number)
+		return revision, err
+	})
+	server.Register("Estimates.Diff", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var target rpcDiff
+		if err := rpcParams(params, &target); err != nil {
+			return nil, err
+		}
+		return services.DiffRevisions(ctx, target.ID, target.From, target.To)
+	})
+
+	return server
+}
+
+// rpcParams decodes params as strictly as the REST routes decode bodies.
+// Absent params are an empty object.
+func rpcParams(params json.RawMessage, target interface{}) error {
+	if len(params) == 0 {
+		params = json.RawMessage("{}")
+	}
+
+	err := decoder.Strict(params, target)
+	var fields decoder.Errors
+	if errors.As(err, &fields) {
+		return newBodyProblem(fields)
+	}
+	return err
+}
+
+// rpcError sends the problem the REST routes would answer as the data of the
+// error, with a code chosen by its status. Internal errors are logged, as
+// RequestLogger does for the REST routes, since the problem hides them.
+func rpcError(ctx context.Context, err error) *jsonrpc.Error {
+	problem := problemFrom(err)
+	if problem.Status >= http.StatusInternalServerError {
+		paint.ContextLogger(ctx).Error("rpc call failed", "error", err)
+	}
+
+	code := jsonrpc.CodeInternalError
+	switch {
+	case problem.Status == http.StatusNotFound:
+		code = rpcCodeNotFound
+	case problem.Status < http.StatusInternalServerError:
+		code = jsonrpc.CodeInvalidParams
+	}
+
+	return &jsonrpc.Error{Code: code, Message: problem.Title, Data: problem}
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"digitalrepublic/api/web"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Home serves the page that calculates rooms from the browser.
+func Home() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.Send(web.Page)
+	}
+}
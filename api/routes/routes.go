@@ -2,9 +2,39 @@ package routes
 
 import (
 	"digitalrepublic/api/handlers"
+	"digitalrepublic/pkg/apikey"
+	"digitalrepublic/pkg/catalog"
+	"digitalrepublic/pkg/service"
 	"github.com/gofiber/fiber/v2"
 )
 
-func Router(app fiber.Router) {
-	app.Get("/amount-of-paint", handlers.PaintSizes())
+func Router(app fiber.Router, services *service.Service, prices catalog.Catalog) {
+	app.Get("/amount-of-paint", handlers.PaintSizes(services))
+	// Browsers cannot send a body with GET, so the page at / posts instead.
+	app.Post("/amount-of-paint", handlers.PaintSizes(services))
+	app.Post("/batch", handlers.Batch(services))
+	app.Get("/live", handlers.LiveRoom(services))
+
+	app.Post("/estimates", handlers.CreateEstimate(services))
+	app.Get("/estimates", handlers.ListEstimates(services))
+	app.Get("/estimates/:id", handlers.GetEstimate(services))
+	app.Put("/estimates/:id", handlers.UpdateEstimate(services))
+	app.Delete("/estimates/:id", handlers.DeleteEstimate(services))
+	app.Get("/estimates/:id/revisions", handlers.ListRevisions(services))
+	app.Get("/estimates/:id/revisions/:number", handlers.GetRevision(services))
+	app.Get("/estimates/:id/diff", handlers.DiffRevisions(services))
+	app.Get("/estimates/:id/quote.pdf", handlers.EstimateQuote(services, prices))
+	app.Get("/estimates/:id/export", handlers.ExportEstimate(services))
+
+	app.Post("/imports", handlers.ImportWalls(services))
+
+	app.Post("/rpc", handlers.RPC(services))
+}
+
+// Admin registers the routes that manage API keys. Issued keys get
+// defaultRateLimit requests per window unless the request sets another one.
+func Admin(app fiber.Router, keys apikey.Store, defaultRateLimit int) {
+	app.Post("/keys", handlers.IssueKey(keys, defaultRateLimit))
+	app.Get("/keys", handlers.ListKeys(keys))
+	app.Delete("/keys/:id", handlers.RevokeKey(keys))
 }
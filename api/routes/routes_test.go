@@ -0,0 +1,159 @@
+package routes
+
+import (
+	"digitalrepublic/api/docs"
+	"digitalrepublic/api/handlers"
+	"digitalrepublic/pkg/apikey"
+	"digitalrepublic/pkg/catalog"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// documentedPrefixes are the groups registered by this package; the other
+// paths of the document are registered by the server.
+var documentedPrefixes = []string{"/api/v1/", "/admin/"}
+
+var pathParameter = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+func registeredRoutes() []string {
+	app := fiber.New()
+	Router(app.Group("/api/v1"), service.New(paint.NewCalculateRoomPaintInCans(), estimate.NewMemoryRepository()), catalog.Default())
+	Admin(app.Group("/admin"), apikey.NewMemoryStore(), 100)
+
+	var routes []string
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+		routes = append(routes, route.Method+" "+pathParameter.ReplaceAllString(route.Path, "{$1}"))
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func documentedRoutes(t *testing.T) []string {
+	var document struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	err := json.Unmarshal(docs.OpenAPI, &document)
+	if err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+
+	var routes []string
+	for path, item := range document.Paths {
+		if !hasDocumentedPrefix(path) {
+			continue
+		}
+		for method := range item {
+			if method == "parameters" {
+				continue
+			}
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func hasDocumentedPrefix(path string) bool {
+	for _, prefix := range documentedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRouter_MatchesOpenAPI(t *testing.T) {
+	registered := registeredRoutes()
+	documented := documentedRoutes(t)
+
+	inDocument := map[string]bool{}
+	for _, route := range documented {
+		inDocument[route] = true
+	}
+	inRouter := map[string]bool{}
+	for _, route := range registered {
+		inRouter[route] = true
+	}
+
+	for _, route := range registered {
+		if !inDocument[route] {
+			t.Errorf("%s is registered but missing from api/docs/openapi.json", route)
+		}
+	}
+	for _, route := range documented {
+		if !inRouter[route] {
+			t.Errorf("%s is in api/docs/openapi.json but not registered", route)
+		}
+	}
+}
+
+// brokenRepository fails to list estimates with an error naming its file.
+type brokenRepository struct {
+	estimate.Repository
+}
+
+func (brokenRepository) List() ([]estimate.Estimate, error) {
+	return nil, errors.New("open /var/lib/paint/estimates.json: permission denied")
+}
+
+func TestRouter_HidesInternalErrors(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	Router(app.Group("/api/v1"), service.New(paint.NewCalculateRoomPaintInCans(), brokenRepository{estimate.NewMemoryRepository()}), catalog.Default())
+
+	status, rest := call(t, app, fiber.MethodGet, "/api/v1/estimates", "")
+	if status != fiber.StatusInternalServerError {
+		t.Errorf("GET /estimates status = %d, want %d", status, fiber.StatusInternalServerError)
+	}
+	_, rpc := call(t, app, fiber.MethodPost, "/api/v1/rpc", `{"jsonrpc": "2.0", "id": 1, "method": "Estimates.List"}`)
+
+	for name, body := range map[string]map[string]interface{}{"REST": rest, "RPC": rpc} {
+		data, _ := json.Marshal(body)
+		if strings.Contains(string(data), "/var/lib/paint") {
+			t.Errorf("%s answered %s, want the internal error hidden", name, data)
+		}
+	}
+}
+
+func TestRouter_ExportFormat(t *testing.T) {
+	tests := []struct {
+		name            string
+		format          string
+		wantType        string
+		wantDisposition bool
+	}{
+		{name: "Should_AnswerJSON_When_FormatIsJSON", format: "json", wantType: fiber.MIMEApplicationJSON},
+		{name: "Should_AnswerJSON_When_FormatIsUpperCase", format: "JSON", wantType: fiber.MIMEApplicationJSON},
+		{name: "Should_SendAttachment_When_FormatIsPadded", format: "%20CSV%20", wantType: "text/csv", wantDisposition: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp()
+			_, created := call(t, app, fiber.MethodPost, "/api/v1/estimates", `{"walls": [{"width": 5, "height": 2.5}]}`)
+
+			req := httptest.NewRequest(fiber.MethodGet, "/api/v1/estimates/"+created["id"].(string)+"/export?format="+tt.format, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+
+			if got := resp.Header.Get(fiber.HeaderContentType); !strings.HasPrefix(got, tt.wantType) {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+			if got := resp.Header.Get(fiber.HeaderContentDisposition) != ""; got != tt.wantDisposition {
+				t.Errorf("Content-Disposition set = %v, want %v", got, tt.wantDisposition)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package routes
+
+import (
+	"digitalrepublic/api/handlers"
+	"digitalrepublic/pkg/catalog"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	Router(app.Group("/api/v1"), service.New(paint.NewCalculateRoomPaintInCans(), estimate.NewMemoryRepository()), catalog.Default())
+	return app
+}
+
+func call(t *testing.T, app *fiber.App, method, path, body string) (int, map[string]interface{}) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+
+	data, _ := io.ReadAll(resp.Body)
+	var decoded map[string]interface{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s %s answered %s: %v", method, path, data, err)
+		}
+	}
+	return resp.StatusCode, decoded
+}
+
+// TestRPC_MatchesREST checks that a JSON-RPC call answers with the body of the
+// matching REST route as its result, or with its problem as the error data.
+// With estimate set, an estimate is created first and {id} is replaced by its ID.
+func TestRPC_MatchesREST(t *testing.T) {
+	tests := []struct {
+		name     string
+		estimate string
+		method   string
+		path     string
+		body     string
+		rpc      string
+		params   string
+		wantCode int
+	}{
+		{
+			name:   "Should_ReturnSameResult_When_RoomIsValid",
+			method: fiber.MethodPost,
+			path:   "/api/v1/amount-of-paint",
+			body:   `{"walls": [{"width": 5, "height": 2.5, "door_quantity": 1, "window_quantity": 1}]}`,
+			rpc:    "CalculateRoomPaintInCans.Execute",
+			params: `{"walls": [{"width": 5, "height": 2.5, "door_quantity": 1, "window_quantity": 1}]}`,
+		},
+		{
+			name:     "Should_ReturnSameProblem_When_RoomIsInvalid",
+			method:   fiber.MethodPost,
+			path:     "/api/v1/amount-of-paint",
+			body:     `{"walls": [{"width": 5, "height": 2.5, "door_quantity": -1}]}`,
+			rpc:      "CalculateRoomPaintInCans.Execute",
+			params:   `{"walls": [{"width": 5, "height": 2.5, "door_quantity": -1}]}`,
+			wantCode: -32602,
+		},
+		{
+			name:     "Should_ReturnSameProblem_When_FieldIsUnknown",
+			method:   fiber.MethodPost,
+			path:     "/api/v1/amount-of-paint",
+			body:     `{"walls": [{"width": 5, "height": 2.5, "doors": 1}]}`,
+			rpc:      "CalculateRoomPaintInCans.Execute",
+			params:   `{"walls": [{"width": 5, "height": 2.5, "doors": 1}]}`,
+			wantCode: -32602,
+		},
+		{
+			name:   "Should_ReturnSameResult_When_BatchIsValid",
+			method: fiber.MethodPost,
+			path:   "/api/v1/batch",
+			body:   `{"rooms": [{"id": "sala", "walls": [{"width": 5, "height": 2.5}]}, {"id": "banheiro", "walls": [{"width": 0.1, "height": 2}]}]}`,
+			rpc:    "CalculateRoomPaintInCans.Batch",
+			params: `{"rooms": [{"id": "sala", "walls": [{"width": 5, "height": 2.5}]}, {"id": "banheiro", "walls": [{"width": 0.1, "height": 2}]}]}`,
+		},
+		{
+			name:     "Should_ReturnSameProblem_When_EstimateDoesNotExist",
+			method:   fiber.MethodGet,
+			path:     "/api/v1/estimates/nao-existe",
+			rpc:      "Estimates.Get",
+			params:   `{"id": "nao-existe"}`,
+			wantCode: -32004,
+		},
+		{
+			name:     "Should_ReturnSameRevision_When_NumberExists",
+			estimate: `{"walls": [{"width": 5, "height": 2.5}]}`,
+			method:   fiber.MethodGet,
+			path:     "/api/v1/estimates/{id}/revisions/1",
+			rpc:      "Estimates.Revision",
+			params:   `{"id": "{id}", "number": 1}`,
+		},
+		{
+			name:     "Should_ReturnSameProblem_When_RevisionIsZero",
+			estimate: `{"walls": [{"width": 5, "height": 2.5}]}`,
+			method:   fiber.MethodGet,
+			path:     "/api/v1/estimates/{id}/revisions/0",
+			rpc:      "Estimates.Revision",
+			params:   `{"id": "{id}", "number": 0}`,
+			wantCode: -32004,
+		},
+		{
+			name:     "Should_ReturnSameProblem_When_DiffFromIsZero",
+			estimate: `{"walls": [{"width": 5, "height": 2.5}]}`,
+			method:   fiber.MethodGet,
+			path:     "/api/v1/estimates/{id}/diff?from=0",
+			rpc:      "Estimates.Diff",
+			params:   `{"id": "{id}", "from": 0}`,
+			wantCode: -32004,
+		},
+		{
+			name:     "Should_ReturnSameProblem_When_DiffFromIsNegative",
+			estimate: `{"walls": [{"width": 5, "height": 2.5}]}`,
+			method:   fiber.MethodGet,
+			path:     "/api/v1/estimates/{id}/diff?from=-5",
+			rpc:      "Estimates.Diff",
+			params:   `{"id": "{id}", "from": -5}`,
+			wantCode: -32004,
+		},
+		{
+			name:     "Should_ReturnSameProblem_When_DiffToIsZero",
+			estimate: `{"walls": [{"width": 5, "height": 2.5}]}`,
+			method:   fiber.MethodGet,
+			path:     "/api/v1/estimates/{id}/diff?to=0",
+			rpc:      "Estimates.Diff",
+			params:   `{"id": "{id}", "to": 0}`,
+			wantCode: -32004,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp()
+			path, params := tt.path, tt.params
+			if tt.estimate != "" {
+				_, created := call(t, app, fiber.MethodPost, "/api/v1/estimates", tt.estimate)
+				id, _ := created["id"].(string)
+				path = strings.ReplaceAll(path, "{id}", id)
+				params = strings.ReplaceAll(params, "{id}", id)
+			}
+
+			_, rest := call(t, app, tt.method, path, tt.body)
+			_, rpc := call(t, app, fiber.MethodPost, "/api/v1/rpc", `{"jsonrpc": "2.0", "id": 1, "method": "`+tt.rpc+`", "params": `+params+`}`)
+
+			if tt.wantCode == 0 {
+				if !reflect.DeepEqual(rpc["result"], interface{}(rest)) {
+					t.Errorf("RPC result = %v, want %v", rpc["result"], rest)
+				}
+				return
+			}
+
+			rpcErr, _ := rpc["error"].(map[string]interface{})
+			if code, _ := rpcErr["code"].(float64); int(code) != tt.wantCode {
+				t.Errorf("RPC error = %v, want code %d", rpc["error"], tt.wantCode)
+			}
+			delete(rest, "instance")
+			delete(rest, "request_id")
+			if !reflect.DeepEqual(rpcErr["data"], interface{}(rest)) {
+				t.Errorf("RPC error data = %v, want %v", rpcErr["data"], rest)
+			}
+		})
+	}
+}
+
+func TestRPC_Estimates(t *testing.T) {
+	app := newTestApp()
+	rpc := func(method, params string) map[string]interface{} {
+		_, response := call(t, app, fiber.MethodPost, "/api/v1/rpc", `{"jsonrpc": "2.0", "id": "x", "method": "`+method+`", "params": `+params+`}`)
+		if response["error"] != nil {
+			t.Fatalf("%s error = %v", method, response["error"])
+		}
+		return response
+	}
+
+	created := rpc("Estimates.Create", `{"walls": [{"width": 5, "height": 2.5}]}`)["result"].(map[string]interface{})
+	id := created["id"].(string)
+	rpc("Estimates.Update", `{"id": "`+id+`", "walls": [{"width": 4, "height": 2.5}]}`)
+
+	_, rest := call(t, app, fiber.MethodGet, "/api/v1/estimates/"+id+"/diff", "")
+	if got := rpc("Estimates.Diff", `{"id": "`+id+`"}`)["result"]; !reflect.DeepEqual(got, interface{}(rest)) {
+		t.Errorf("Estimates.Diff = %v, want %v", got, rest)
+	}
+
+	revision := rpc("Estimates.Revision", `{"id": "`+id+`"}`)["result"].(map[string]interface{})
+	if revision["number"] != float64(2) {
+		t.Errorf("Estimates.Revision number = %v, want the latest, 2", revision["number"])
+	}
+
+	rpc("Estimates.Delete", `{"id": "`+id+`"}`)
+	status, _ := call(t, app, fiber.MethodGet, "/api/v1/estimates/"+id, "")
+	if status != fiber.StatusNotFound {
+		t.Errorf("GET deleted estimate status = %d, want %d", status, fiber.StatusNotFound)
+	}
+}
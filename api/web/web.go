@@ -0,0 +1,11 @@
+// Package web embeds the page served at the root of the server, where rooms
+// are calculated from the browser through the API.
+package web
+
+import _ "embed"
+
+// Page is a self-contained HTML page that calls POST /api/v1/amount-of-paint
+// on the same server.
+//
+//go:embed index.html
+var Page []byte
@@ -0,0 +1,41 @@
+package web
+
+import (
+	"digitalrepublic/pkg/entities"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// canLabel writes the size of can the way the page does, e.g. "0,225 L".
+func canLabel(can entities.Can) string {
+	return `"` + strings.Replace(strconv.FormatFloat(can.Liters(), 'f', -1, 64), ".", ",", 1) + ` L"`
+}
+
+func TestPage_CanSizes(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		cans []entities.Can
+	}{
+		{name: "Should_MatchWallCans_When_PageLabelsResults", list: "wallCans", cans: []entities.Can{entities.HugeCan, entities.BigCan, entities.MediumCan, entities.SmallCan}},
+		{name: "Should_MatchEnamelCans_When_PageLabelsResults", list: "enamelCans", cans: []entities.Can{entities.EnamelBigCan, entities.EnamelMediumCan, entities.EnamelSmallCan}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := regexp.MustCompile(`const ` + tt.list + ` = \[(.*)\];`).FindSubmatch(Page)
+			if match == nil {
+				t.Fatalf("index.html does not declare %s", tt.list)
+			}
+
+			want := make([]string, len(tt.cans))
+			for i, can := range tt.cans {
+				want[i] = canLabel(can)
+			}
+			if got := string(match[1]); got != strings.Join(want, ", ") {
+				t.Errorf("%s = [%s], want [%s]", tt.list, got, strings.Join(want, ", "))
+			}
+		})
+	}
+}
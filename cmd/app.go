@@ -1,7 +1,16 @@
 package main
 
 import (
+	"context"
+	"digitalrepublic/pkg/config"
 	serverInit "digitalrepublic/server"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 var (
@@ -9,6 +18,58 @@ var (
 )
 
 func main() {
-	server = serverInit.New()
-	server.Start()
+	os.Exit(run())
+}
+
+// run starts the server and blocks until it fails to listen or a SIGINT or
+// SIGTERM stops it, returning the exit code.
+func run() int {
+	cfg, err := config.Load(os.Args[1:], os.Getenv, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: cfg.LogLevel}))
+	slog.SetDefault(logger)
+
+	server, err = serverInit.New(cfg, logger)
+	if err != nil {
+		logger.Error("could not start server", "error", err)
+		return 1
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- server.Start()
+	}()
+
+	select {
+	case err = <-stopped:
+		logger.Error("server stopped", "error", err)
+		return 1
+	case received := <-signals:
+		logger.Info("gracefully shutting down", "signal", received.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	err = server.Stop(ctx)
+	if err == nil {
+		err = <-stopped
+	}
+	if err != nil {
+		logger.Error("shutdown did not complete", "error", err)
+		return 1
+	}
+
+	logger.Info("server stopped")
+	return 0
 }
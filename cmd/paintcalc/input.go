@@ -0,0 +1,157 @@
+package main
+
+import (
+	"digitalrepublic/pkg/decoder"
+	"digitalrepublic/pkg/paint"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	wallSpecError      = "parede invalida, use LARGURAxALTURA[:doors=N,windows=N,trim=M,paint_doors,paint_frames]"
+	wallOptionError    = "opção de parede desconhecida"
+	wallValueError     = "valor invalido para a opção de parede"
+	roomsAndWallsError = "o arquivo deve ter rooms ou walls, não os dois"
+	noWallsError       = "informe um arquivo ou pelo menos uma parede com --wall"
+	fileAndWallsError  = "use um arquivo ou --wall, não os dois"
+)
+
+// room is one room of a project. A file holding only walls is a project with
+// a single unnamed room.
+type room struct {
+	Name string `json:"name,omitempty"`
+	paint.CalculateRoomPaintInCansInput
+}
+
+type projectFile struct {
+	Rooms []room `json:"rooms"`
+	room
+}
+
+// readProject reads the rooms from a JSON or YAML file, or from standard input
+// when path is "-". Files ending in .json are read as JSON and anything else as
+// YAML, which also accepts JSON.
+func readProject(path string, stdin io.Reader) (rooms []room, single bool, err error) {
+	var data []byte
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !strings.EqualFold(filepath.Ext(path), ".json") {
+		var document interface{}
+		err = yaml.Unmarshal(data, &document)
+		if err != nil {
+			return nil, false, err
+		}
+		data, err = json.Marshal(document)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	// Decoded as strictly as the API decodes bodies, so a misspelt field is
+	// reported instead of silently left out of the estimate.
+	var file projectFile
+	err = decoder.Strict(data, &file)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch {
+	case len(file.Rooms) > 0 && len(file.Walls) > 0:
+		return nil, false, errors.New(roomsAndWallsError)
+	case len(file.Rooms) > 0:
+		return file.Rooms, false, nil
+	default:
+		return []room{file.room}, true, nil
+	}
+}
+
+// wallFlags collects the repeatable --wall flag.
+type wallFlags []paint.WallInput
+
+func (w *wallFlags) String() string {
+	return fmt.Sprint(len(*w), " paredes")
+}
+
+func (w *wallFlags) Set(spec string) error {
+	wall, err := parseWall(spec)
+	if err != nil {
+		return err
+	}
+	*w = append(*w, wall)
+	return nil
+}
+
+// parseWall reads a wall such as "5x2.5:doors=1,windows=1". Dimensions are in
+// meters and may use a decimal comma; boolean options may omit the value.
+func parseWall(spec string) (paint.WallInput, error) {
+	var wall paint.WallInput
+
+	dimensions, options, _ := strings.Cut(spec, ":")
+	width, height, ok := strings.Cut(strings.ToLower(dimensions), "x")
+	if !ok {
+		return wall, errors.New(wallSpecError)
+	}
+
+	var err error
+	wall.Width, err = parseDecimal(width)
+	if err != nil {
+		return wall, errors.New(wallSpecError)
+	}
+	wall.Height, err = parseDecimal(height)
+	if err != nil {
+		return wall, errors.New(wallSpecError)
+	}
+
+	if options == "" {
+		return wall, nil
+	}
+	for _, option := range strings.Split(options, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(option), "=")
+
+		switch key {
+		case "doors":
+			wall.DoorQuantity, err = strconv.Atoi(value)
+		case "windows":
+			wall.WindowQuantity, err = strconv.Atoi(value)
+		case "trim":
+			wall.TrimLength, err = parseDecimal(value)
+		case "paint_doors":
+			wall.PaintDoors, err = parseFlag(value, hasValue)
+		case "paint_frames":
+			wall.PaintFrames, err = parseFlag(value, hasValue)
+		default:
+			return wall, fmt.Errorf("%s: %s", wallOptionError, key)
+		}
+		if err != nil {
+			return wall, fmt.Errorf("%s: %s", wallValueError, key)
+		}
+	}
+
+	return wall, nil
+}
+
+func parseDecimal(value string) (float64, error) {
+	return strconv.ParseFloat(strings.Replace(strings.TrimSpace(value), ",", ".", 1), 64)
+}
+
+func parseFlag(value string, hasValue bool) (bool, error) {
+	if !hasValue {
+		return true, nil
+	}
+	return strconv.ParseBool(value)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"digitalrepublic/pkg/paint"
+	"reflect"
+	"testing"
+)
+
+func TestParseWall(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    paint.WallInput
+		wantErr bool
+	}{
+		{name: "Should_ReadDimensions_When_SpecHasNoOptions", spec: "5x2.5", want: paint.WallInput{Width: 5, Height: 2.5}},
+		{name: "Should_AcceptDecimalComma_When_DimensionsUseIt", spec: "3,5X2,5", want: paint.WallInput{Width: 3.5, Height: 2.5}},
+		{
+			name: "Should_ReadOptions_When_SpecHasThem",
+			spec: "5x2.5:doors=1, windows=2,trim=4,paint_doors,paint_frames=false",
+			want: paint.WallInput{Width: 5, Height: 2.5, DoorQuantity: 1, WindowQuantity: 2, TrimLength: 4, PaintDoors: true},
+		},
+		{name: "Should_ReturnError_When_HeightIsMissing", spec: "5", wantErr: true},
+		{name: "Should_ReturnError_When_WidthIsNotANumber", spec: "cincox2.5", wantErr: true},
+		{name: "Should_ReturnError_When_QuantityIsNotAnInteger", spec: "5x2.5:doors=1.5", wantErr: true},
+		{name: "Should_ReturnError_When_OptionIsUnknown", spec: "5x2.5:color=azul", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWall(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWall() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWall() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Command paintcalc calculates the paint needed for a room or a project
+// without the API, for use where there is no connection.
+//
+//	paintcalc --wall 5x2.5:doors=1,windows=1 --wall 4x2.5
+//	paintcalc -format json projeto.yaml
+//	paintcalc -i
+//
+// It exits with 1 when a room is invalid, with 2 on usage errors and with 3
+// when the results cannot be written.
+package main
+
+import (
+	"context"
+	"digitalrepublic/pkg/paint"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK         = 0
+	exitValidation = 1
+	exitUsage      = 2
+	exitIO         = 3
+)
+
+const (
+	formatError             = "formato invalido, use table, json ou csv"
+	interactiveProjectError = "o modo interativo edita um ambiente por vez, não um projeto"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("paintcalc", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "uso: paintcalc [opções] [arquivo.json|arquivo.yaml|-]")
+		flags.PrintDefaults()
+	}
+
+	var walls wallFlags
+	flags.Var(&walls, "wall", "parede em metros, ex. 5x2.5:doors=1,windows=1 (opções: doors, windows, trim, paint_doors, paint_frames); pode ser repetida")
+	format := flags.String("format", formatTable, "saída: table, json ou csv")
+	interactive := flags.Bool("i", false, "monta o ambiente parede por parede e salva em um arquivo")
+
+	err := flags.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *format != formatTable && *format != formatJSON && *format != formatCSV {
+		return usageError(stderr, errors.New(formatError))
+	}
+
+	var rooms []room
+	single := true
+	switch {
+	case flags.NArg() > 1 || (flags.NArg() == 1 && len(walls) > 0):
+		return usageError(stderr, errors.New(fileAndWallsError))
+	case flags.NArg() == 1:
+		rooms, single, err = readProject(flags.Arg(0), stdin)
+		if err != nil {
+			return usageError(stderr, err)
+		}
+	case len(walls) > 0:
+		rooms = []room{{CalculateRoomPaintInCansInput: paint.CalculateRoomPaintInCansInput{Walls: walls}}}
+	case !*interactive:
+		return usageError(stderr, errors.New(noWallsError))
+	}
+
+	interactor := paint.NewCalculateRoomPaintInCans()
+	if *interactive {
+		return runInteractive(stdin, stdout, stderr, interactor, rooms, single)
+	}
+
+	results := make([]roomResult, 0, len(rooms))
+	failed := false
+	for i, r := range rooms {
+		result, err := interactor.Execute(context.Background(), r.CalculateRoomPaintInCansInput)
+		if err != nil {
+			failed = true
+			fmt.Fprintf(stderr, "paintcalc: %s: %v\n", roomLabel(r, i, single), err)
+			continue
+		}
+		results = append(results, roomResult{Name: r.Name, Result: result})
+	}
+	if failed {
+		return exitValidation
+	}
+
+	err = writeResults(stdout, *format, results, single)
+	if err != nil {
+		fmt.Fprintf(stderr, "paintcalc: %v\n", err)
+		return exitIO
+	}
+
+	return exitOK
+}
+
+// runInteractive starts the wizard, from the room given as a file or flags
+// when there is one.
+func runInteractive(stdin io.Reader, stdout, stderr io.Writer, interactor paint.CalculateRoomPaintInCans, rooms []room, single bool) int {
+	if !single {
+		return usageError(stderr, errors.New(interactiveProjectError))
+	}
+
+	var start room
+	if len(rooms) > 0 {
+		start = rooms[0]
+	}
+
+	_, err := runWizard(stdin, stdout, interactor, start)
+	if err != nil {
+		fmt.Fprintf(stderr, "paintcalc: %v\n", err)
+		return exitValidation
+	}
+
+	return exitOK
+}
+
+func roomLabel(r room, index int, single bool) string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case single:
+		return "ambiente"
+	default:
+		return fmt.Sprintf("ambiente %d", index+1)
+	}
+}
+
+func usageError(stderr io.Writer, err error) int {
+	fmt.Fprintf(stderr, "paintcalc: %v\n", err)
+	return exitUsage
+}
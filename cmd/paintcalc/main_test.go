@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "projeto.yaml")
+	if err := os.WriteFile(project, []byte("rooms:\n  - name: sala\n    walls:\n      - {width: 5, height: 2.5}\n  - name: quarto\n    walls:\n      - {width: 4, height: 2.5}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	misspelt := filepath.Join(dir, "errado.json")
+	if err := os.WriteFile(misspelt, []byte(`{"walls": [{"width": 5, "height": 2.5, "doors": 1}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "Should_PrintTable_When_WallsAreGiven",
+			args:       []string{"--wall", "5x2.5"},
+			wantCode:   exitOK,
+			wantStdout: "Total   12.5       2.5\n",
+		},
+		{
+			name:       "Should_PrintAPIExport_When_FormatIsJSON",
+			args:       []string{"-format", "json", "--wall", "5x2,5"},
+			wantCode:   exitOK,
+			wantStdout: `{"huge_can":0,"big_can":0,"medium_can":1,"small_can":0,"area":12.5,"liters":2.5,"walls":[{"area":12.5,"liters":2.5}]}` + "\n",
+		},
+		{
+			name:       "Should_PrintCSV_When_FormatIsCSV",
+			args:       []string{"-format", "csv", "--wall", "5x2.5"},
+			wantCode:   exitOK,
+			wantStdout: "total,,12.5,2.5,,\n",
+		},
+		{
+			name:       "Should_NameEachRoom_When_ProjectIsCSV",
+			args:       []string{"-format", "csv", project},
+			wantCode:   exitOK,
+			wantStdout: "quarto,wall,1,10,2,,\n",
+		},
+		{
+			name:       "Should_ReadStdin_When_PathIsDash",
+			args:       []string{"-format", "json", "-"},
+			stdin:      `{"walls": [{"width": 5, "height": 2.5}]}`,
+			wantCode:   exitOK,
+			wantStdout: `"area":12.5`,
+		},
+		{
+			name:       "Should_ExitValidation_When_RoomIsInvalid",
+			args:       []string{"--wall", "0.5x0.5"},
+			wantCode:   exitValidation,
+			wantStderr: "paintcalc: ambiente: tamanho da parede invalido",
+		},
+		{
+			name:       "Should_ExitUsage_When_FieldIsUnknown",
+			args:       []string{misspelt},
+			wantCode:   exitUsage,
+			wantStderr: "/walls/0/doors: campo desconhecido",
+		},
+		{
+			name:       "Should_ExitUsage_When_WallIsMalformed",
+			args:       []string{"--wall", "5por2.5"},
+			wantCode:   exitUsage,
+			wantStderr: wallSpecError,
+		},
+		{
+			name:       "Should_ExitUsage_When_WallOptionIsUnknown",
+			args:       []string{"--wall", "5x2.5:portas=1"},
+			wantCode:   exitUsage,
+			wantStderr: wallOptionError + ": portas",
+		},
+		{
+			name:       "Should_ExitUsage_When_FormatIsUnknown",
+			args:       []string{"-format", "xml", "--wall", "5x2.5"},
+			wantCode:   exitUsage,
+			wantStderr: formatError,
+		},
+		{
+			name:       "Should_ExitUsage_When_FileAndWallsAreGiven",
+			args:       []string{"--wall", "5x2.5", project},
+			wantCode:   exitUsage,
+			wantStderr: fileAndWallsError,
+		},
+		{
+			name:       "Should_ExitUsage_When_NothingIsGiven",
+			wantCode:   exitUsage,
+			wantStderr: noWallsError,
+		},
+		{
+			name:     "Should_ExitOK_When_HelpIsAsked",
+			args:     []string{"-h"},
+			wantCode: exitOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			if code != tt.wantCode {
+				t.Errorf("run() = %d, want %d (stderr %q)", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("run() stdout = %q, want it to contain %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("run() stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disco cheio")
+}
+
+func TestRun_WriteFails(t *testing.T) {
+	for _, format := range []string{formatTable, formatJSON, formatCSV} {
+		t.Run("Should_ExitIO_When_Format"+format+"CannotBeWritten", func(t *testing.T) {
+			var stderr strings.Builder
+			code := run([]string{"-format", format, "--wall", "5x2.5"}, strings.NewReader(""), failingWriter{}, &stderr)
+
+			if code != exitIO {
+				t.Errorf("run() = %d, want %d", code, exitIO)
+			}
+			if !strings.Contains(stderr.String(), "disco cheio") {
+				t.Errorf("run() stderr = %q, want the write error", stderr.String())
+			}
+		})
+	}
+}
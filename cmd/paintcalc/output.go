@@ -0,0 +1,111 @@
+package main
+
+import (
+	"digitalrepublic/pkg/exporter"
+	"digitalrepublic/pkg/paint"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+type roomResult struct {
+	Name   string                                `json:"name,omitempty"`
+	Result *paint.CalculateRoomPaintInCansOutput `json:"result"`
+}
+
+// writeResults prints the results. A single room read from walls alone is
+// printed exactly as the API exports it; projects name each room.
+func writeResults(w io.Writer, format string, results []roomResult, single bool) error {
+	switch format {
+	case formatJSON:
+		if single {
+			return json.NewEncoder(w).Encode(results[0].Result)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Rooms []roomResult `json:"rooms"`
+		}{results})
+
+	case formatCSV:
+		writer := csv.NewWriter(w)
+		if single {
+			return writer.WriteAll(exporter.CSVRecords(*results[0].Result))
+		}
+		for i, result := range results {
+			for j, record := range exporter.CSVRecords(*result.Result) {
+				if j == 0 && i > 0 {
+					continue
+				}
+				name := result.Name
+				if j == 0 {
+					name = "room"
+				}
+				writer.Write(append([]string{name}, record...))
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	default:
+		sticky := &stickyWriter{w: w}
+		for i, result := range results {
+			if i > 0 {
+				fmt.Fprintln(sticky)
+			}
+			writeTable(sticky, result)
+		}
+		return sticky.err
+	}
+}
+
+// stickyWriter keeps the first write error, so the tables can be printed
+// without checking every line.
+type stickyWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (s *stickyWriter) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.w.Write(p)
+	s.err = err
+	return n, err
+}
+
+func writeTable(w io.Writer, result roomResult) {
+	if result.Name != "" {
+		fmt.Fprintf(w, "%s\n\n", result.Name)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Parede\tÁrea (m²)\tTinta (L)")
+	for i, wall := range result.Result.Walls {
+		fmt.Fprintf(table, "%d\t%s\t%s\n", i+1, formatNumber(wall.Area), formatNumber(wall.Liters))
+	}
+	fmt.Fprintf(table, "Total\t%s\t%s\n", formatNumber(result.Result.Area), formatNumber(result.Result.Liters))
+	table.Flush()
+
+	fmt.Fprintln(w)
+	table = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Tinta\tLata (L)\tQtd.")
+	for _, line := range exporter.CanLines(*result.Result) {
+		fmt.Fprintf(table, "%s\t%s\t%d\n", line.Paint, formatNumber(line.Size.Liters()), line.Quantity)
+	}
+	table.Flush()
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,412 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/exporter"
+	"digitalrepublic/pkg/paint"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	inputClosedError   = "entrada encerrada antes de salvar o ambiente"
+	dimensionsError    = "informe largura e altura em metros, ex. 5x2.5"
+	quantityError      = "informe um número inteiro"
+	answerError        = "responda s ou n"
+	lengthError        = "informe um comprimento em metros"
+	wallNumberError    = "número de parede invalido"
+	menuOptionError    = "opção invalida"
+	emptyRoomSaveError = "adicione pelo menos uma parede antes de salvar"
+)
+
+// backAnswer returns to the previous question of a wall.
+const backAnswer = "<"
+
+const defaultRoomFile = "ambiente.json"
+
+var (
+	errBack        = errors.New("voltar")
+	errInputClosed = errors.New(inputClosedError)
+)
+
+// wizard builds a room by asking for each wall on in and writing prompts on
+// out. Every answer is checked with the same rules the calculation applies,
+// so the saved room is always valid.
+type wizard struct {
+	in         *bufio.Scanner
+	out        io.Writer
+	interactor paint.CalculateRoomPaintInCans
+	room       room
+}
+
+// question asks for one field of a wall. show returns the current value,
+// offered as the default when editing, and set parses an answer into it.
+type question struct {
+	prompt string
+	show   func(wall paint.WallInput) string
+	set    func(wall *paint.WallInput, answer string) error
+}
+
+var wallQuestions = []question{
+	{
+		prompt: "Largura x altura (m)",
+		show: func(wall paint.WallInput) string {
+			return formatNumber(wall.Width) + "x" + formatNumber(wall.Height)
+		},
+		set: func(wall *paint.WallInput, answer string) error {
+			parsed, err := parseWall(answer)
+			if err != nil || strings.Contains(answer, ":") {
+				return errors.New(dimensionsError)
+			}
+			wall.Width, wall.Height = parsed.Width, parsed.Height
+			return nil
+		},
+	},
+	{
+		prompt: "Portas",
+		show:   func(wall paint.WallInput) string { return strconv.Itoa(wall.DoorQuantity) },
+		set: func(wall *paint.WallInput, answer string) (err error) {
+			wall.DoorQuantity, err = parseQuantity(answer)
+			return err
+		},
+	},
+	{
+		prompt: "Janelas",
+		show:   func(wall paint.WallInput) string { return strconv.Itoa(wall.WindowQuantity) },
+		set: func(wall *paint.WallInput, answer string) (err error) {
+			wall.WindowQuantity, err = parseQuantity(answer)
+			return err
+		},
+	},
+	{
+		prompt: "Pintar as portas com esmalte (s/n)",
+		show:   func(wall paint.WallInput) string { return formatYesNo(wall.PaintDoors) },
+		set: func(wall *paint.WallInput, answer string) (err error) {
+			wall.PaintDoors, err = parseYesNo(answer)
+			return err
+		},
+	},
+	{
+		prompt: "Pintar os batentes com esmalte (s/n)",
+		show:   func(wall paint.WallInput) string { return formatYesNo(wall.PaintFrames) },
+		set: func(wall *paint.WallInput, answer string) (err error) {
+			wall.PaintFrames, err = parseYesNo(answer)
+			return err
+		},
+	},
+	{
+		prompt: "Rodapé (m)",
+		show:   func(wall paint.WallInput) string { return formatNumber(wall.TrimLength) },
+		set: func(wall *paint.WallInput, answer string) error {
+			length, err := parseDecimal(answer)
+			if err != nil {
+				return errors.New(lengthError)
+			}
+			wall.TrimLength = length
+			return nil
+		},
+	},
+}
+
+// runWizard edits the room until it is saved, returning the path it was
+// saved to.
+func runWizard(in io.Reader, out io.Writer, interactor paint.CalculateRoomPaintInCans, start room) (string, error) {
+	w := &wizard{in: bufio.NewScanner(in), out: out, interactor: interactor, room: start}
+
+	fmt.Fprintln(out, "Monte o ambiente parede por parede. Responda < para voltar à pergunta anterior")
+	fmt.Fprintln(out, "e deixe em branco para manter o valor entre colchetes.")
+
+	if w.room.Name == "" {
+		name, err := w.ask("Nome do ambiente", "")
+		if err != nil {
+			return "", err
+		}
+		w.room.Name = name
+	}
+	if len(w.room.Walls) == 0 {
+		err := w.addWall()
+		if err != nil && !errors.Is(err, errBack) {
+			return "", err
+		}
+	}
+
+	for {
+		w.summary()
+
+		answer, err := w.ask("[a]dicionar, [e]ditar N, [r]emover N, [s]alvar", "")
+		if err != nil {
+			return "", err
+		}
+
+		command, argument, _ := strings.Cut(strings.ToLower(answer), " ")
+		switch command {
+		case "a", "adicionar":
+			err = w.addWall()
+		case "e", "editar":
+			err = w.editWall(argument)
+		case "r", "remover":
+			err = w.removeWall(argument)
+		case "s", "salvar":
+			if len(w.room.Walls) == 0 {
+				err = errors.New(emptyRoomSaveError)
+				break
+			}
+			return w.save()
+		default:
+			err = errors.New(menuOptionError)
+		}
+
+		if errors.Is(err, errBack) {
+			continue
+		}
+		if err != nil {
+			if errors.Is(err, errInputClosed) {
+				return "", err
+			}
+			fmt.Fprintf(out, "  %v\n", err)
+		}
+	}
+}
+
+func (w *wizard) addWall() error {
+	err := checkWallLimit(len(w.room.Walls))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w.out, "\nParede %d\n", len(w.room.Walls)+1)
+	wall, err := w.askWall(paint.WallInput{}, false)
+	if err != nil {
+		return err
+	}
+
+	w.room.Walls = append(w.room.Walls, wall)
+	return nil
+}
+
+func (w *wizard) editWall(argument string) error {
+	index, err := w.wallIndex(argument)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w.out, "\nParede %d\n", index+1)
+	wall, err := w.askWall(w.room.Walls[index], true)
+	if err != nil {
+		return err
+	}
+
+	w.room.Walls[index] = wall
+	return nil
+}
+
+func (w *wizard) removeWall(argument string) error {
+	index, err := w.wallIndex(argument)
+	if err != nil {
+		return err
+	}
+
+	w.room.Walls = append(w.room.Walls[:index], w.room.Walls[index+1:]...)
+	return nil
+}
+
+func (w *wizard) wallIndex(argument string) (int, error) {
+	number, err := strconv.Atoi(strings.TrimSpace(argument))
+	if err != nil || number < 1 || number > len(w.room.Walls) {
+		return 0, errors.New(wallNumberError)
+	}
+	return number - 1, nil
+}
+
+// askWall goes through the wall questions, validating the wall as answered so
+// far after each one. Answering < on the first question returns errBack.
+func (w *wizard) askWall(wall paint.WallInput, editing bool) (paint.WallInput, error) {
+	for i := 0; i < len(wallQuestions); {
+		q := wallQuestions[i]
+
+		current := ""
+		if editing || i > 0 {
+			current = q.show(wall)
+		}
+		answer, err := w.ask(q.prompt, current)
+		if err != nil {
+			return wall, err
+		}
+
+		if answer == backAnswer {
+			if i == 0 {
+				return wall, errBack
+			}
+			i--
+			continue
+		}
+		if answer == "" {
+			answer = current
+		}
+
+		candidate := wall
+		err = q.set(&candidate, answer)
+		if err == nil {
+			err = paint.ValidateWall(answeredUpTo(candidate, i))
+		}
+		if err != nil {
+			fmt.Fprintf(w.out, "  %v\n", err)
+			continue
+		}
+
+		wall = candidate
+		i++
+	}
+
+	return wall, nil
+}
+
+// answeredUpTo keeps only the fields of the questions up to index, so a
+// question is not rejected because of a later answer that is still to be
+// reviewed.
+func answeredUpTo(wall paint.WallInput, index int) paint.WallInput {
+	partial := paint.WallInput{Width: wall.Width, Height: wall.Height}
+	fields := []func(){
+		func() { partial.DoorQuantity = wall.DoorQuantity },
+		func() { partial.WindowQuantity = wall.WindowQuantity },
+		func() { partial.PaintDoors = wall.PaintDoors },
+		func() { partial.PaintFrames = wall.PaintFrames },
+		func() { partial.TrimLength = wall.TrimLength },
+	}
+	for i := 0; i < index && i < len(fields); i++ {
+		fields[i]()
+	}
+	partial.Bands = wall.Bands
+
+	return partial
+}
+
+// summary lists the walls and the cans needed so far.
+func (w *wizard) summary() {
+	fmt.Fprintln(w.out)
+	if len(w.room.Walls) == 0 {
+		fmt.Fprintln(w.out, "Nenhuma parede ainda.")
+		return
+	}
+
+	for i, wall := range w.room.Walls {
+		fmt.Fprintf(w.out, "%d. %s x %s m, %d porta(s), %d janela(s)\n",
+			i+1, formatNumber(wall.Width), formatNumber(wall.Height), wall.DoorQuantity, wall.WindowQuantity)
+	}
+
+	result, err := w.interactor.Execute(context.Background(), w.room.CalculateRoomPaintInCansInput)
+	if err != nil {
+		fmt.Fprintf(w.out, "  %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(w.out, "Área: %s m²  Tinta: %s L\n", formatNumber(result.Area), formatNumber(result.Liters))
+	for _, line := range exporter.CanLines(*result) {
+		fmt.Fprintf(w.out, "  %d x %s L %s\n", line.Quantity, formatNumber(line.Size.Liters()), line.Paint)
+	}
+}
+
+func (w *wizard) save() (string, error) {
+	for {
+		path, err := w.ask("Salvar em", defaultRoomFile)
+		if err != nil {
+			return "", err
+		}
+		if path == "" {
+			path = defaultRoomFile
+		}
+
+		err = writeRoom(path, w.room)
+		if err != nil {
+			fmt.Fprintf(w.out, "  %v\n", err)
+			continue
+		}
+
+		fmt.Fprintf(w.out, "Ambiente salvo em %s\n", path)
+		return path, nil
+	}
+}
+
+func (w *wizard) ask(prompt, current string) (string, error) {
+	if current != "" {
+		fmt.Fprintf(w.out, "%s [%s]: ", prompt, current)
+	} else {
+		fmt.Fprintf(w.out, "%s: ", prompt)
+	}
+
+	if !w.in.Scan() {
+		fmt.Fprintln(w.out)
+		if w.in.Err() != nil {
+			return "", w.in.Err()
+		}
+		return "", errInputClosed
+	}
+	return strings.TrimSpace(w.in.Text()), nil
+}
+
+// writeRoom saves the room in the format paintcalc reads, as YAML when the
+// path ends in .yaml or .yml and as JSON otherwise.
+func writeRoom(path string, r room) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var document interface{}
+		err = json.Unmarshal(data, &document)
+		if err != nil {
+			return err
+		}
+		data, err = yaml.Marshal(document)
+		if err != nil {
+			return err
+		}
+	default:
+		data = append(data, '\n')
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// checkWallLimit tells whether a room with count walls can take another one.
+func checkWallLimit(count int) error {
+	room := entities.Room{Walls: make([]entities.Wall, count)}
+	return room.AddWall(entities.Wall{})
+}
+
+func parseQuantity(answer string) (int, error) {
+	quantity, err := strconv.Atoi(answer)
+	if err != nil {
+		return 0, errors.New(quantityError)
+	}
+	return quantity, nil
+}
+
+func parseYesNo(answer string) (bool, error) {
+	switch strings.ToLower(answer) {
+	case "s", "sim", "y", "yes":
+		return true, nil
+	case "n", "não", "nao", "no":
+		return false, nil
+	}
+	return false, errors.New(answerError)
+}
+
+func formatYesNo(value bool) string {
+	if value {
+		return "s"
+	}
+	return "n"
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"digitalrepublic/pkg/paint"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunWizard(t *testing.T) {
+	wall := paint.WallInput{Width: 5, Height: 2.5, DoorQuantity: 1, WindowQuantity: 1, PaintDoors: true}
+
+	tests := []struct {
+		name       string
+		start      room
+		answers    []string
+		wantWalls  []paint.WallInput
+		wantErr    error
+		wantOutput []string
+	}{
+		{
+			name:       "Should_SaveRoom_When_SessionIsValid",
+			answers:    []string{"sala", "5x2.5", "1", "1", "s", "n", "", "s", "{path}"},
+			wantWalls:  []paint.WallInput{wall},
+			wantOutput: []string{"Parede 1", "1. 5 x 2.5 m, 1 porta(s), 1 janela(s)", "Área: 8.58 m²", "Ambiente salvo em"},
+		},
+		{
+			name:       "Should_AskAgain_When_AnswersAreInvalid",
+			answers:    []string{"sala", "cinco", "5x2,5", "um", "-1", "1", "1", "talvez", "s", "n", "", "z", "s", "{path}"},
+			wantWalls:  []paint.WallInput{wall},
+			wantOutput: []string{dimensionsError, quantityError, "a quantidade de portas não pode ser menor do que zero", answerError, menuOptionError},
+		},
+		{
+			name:       "Should_AskAgain_When_WallBreaksARule",
+			answers:    []string{"sala", "5x2", "1", "<", "5x2.5", "1", "1", "s", "n", "", "s", "{path}"},
+			wantWalls:  []paint.WallInput{wall},
+			wantOutput: []string{"a altura mínima da parede deve ser 30 centímetros a mais do que a altura da porta"},
+		},
+		{
+			name:       "Should_EditAndRemoveWalls_When_MenuIsUsed",
+			start:      room{Name: "sala", CalculateRoomPaintInCansInput: paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{{Width: 4, Height: 2.5}, wall}}},
+			answers:    []string{"e 1", "3x2.5", "", "", "", "", "", "r 2", "r 5", "s", "{path}"},
+			wantWalls:  []paint.WallInput{{Width: 3, Height: 2.5}},
+			wantOutput: []string{"Parede 1", wallNumberError},
+		},
+		{
+			name:    "Should_ReturnInputClosed_When_InputEndsBeforeSaving",
+			answers: []string{"sala", "5x2.5", "1"},
+			wantErr: errInputClosed,
+		},
+		{
+			name:       "Should_ReturnInputClosed_When_InputEndsWithoutWalls",
+			answers:    []string{"sala", "<", "s"},
+			wantErr:    errInputClosed,
+			wantOutput: []string{"Nenhuma parede ainda.", emptyRoomSaveError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ambiente.yaml")
+			in := strings.ReplaceAll(strings.Join(tt.answers, "\n")+"\n", "{path}", path)
+
+			var out strings.Builder
+			saved, err := runWizard(strings.NewReader(in), &out, paint.NewCalculateRoomPaintInCans(), tt.start)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("runWizard() error = %v, want %v\n%s", err, tt.wantErr, out.String())
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("runWizard() output is missing %q in\n%s", want, out.String())
+				}
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if saved != path {
+				t.Errorf("runWizard() = %q, want %q", saved, path)
+			}
+			rooms, single, err := readProject(saved, nil)
+			if err != nil {
+				t.Fatalf("readProject() error = %v", err)
+			}
+			if !single || rooms[0].Name != "sala" || !reflect.DeepEqual(rooms[0].Walls, tt.wantWalls) {
+				t.Errorf("saved room = %+v, want sala with walls %+v", rooms, tt.wantWalls)
+			}
+		})
+	}
+}
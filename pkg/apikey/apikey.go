@@ -0,0 +1,126 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	notFoundError    = "chave de API não encontrada"
+	invalidKeyError  = "chave de API invalida"
+	revokedKeyError  = "chave de API revogada"
+	missingNameError = "é necessario informar o nome da chave"
+	rateLimitError   = "o limite de requisições da chave deve ser maior que 0"
+	dailyQuotaError  = "a cota diária da chave não pode ser negativa"
+)
+
+var (
+	ErrNotFound   = errors.New(notFoundError)
+	ErrInvalidKey = errors.New(invalidKeyError)
+	ErrRevoked    = errors.New(revokedKeyError)
+)
+
+// secretPrefix starts every secret so keys are easy to spot, e.g. in logs
+// or leaked configuration.
+const secretPrefix = "pk_"
+
+// Key is an API key as stored: only the SHA-256 of the secret is kept, so
+// the secret itself is shown once, when the key is issued.
+type Key struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Prefix is the start of the secret, enough to tell keys apart.
+	Prefix string `json:"prefix"`
+	Hash   string `json:"hash"`
+	// RateLimit is the number of requests allowed per rate window.
+	RateLimit int `json:"rate_limit"`
+	// DailyQuota is the number of requests allowed per UTC day; 0 is unlimited.
+	DailyQuota int        `json:"daily_quota"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k Key) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Store keeps the issued keys. Create assigns the ID and creation time.
+type Store interface {
+	Create(key Key) (Key, error)
+	List() ([]Key, error)
+	FindByHash(hash string) (Key, error)
+	Revoke(id string) (Key, error)
+}
+
+// Issue creates a key and returns it with its secret, which cannot be
+// recovered afterwards.
+func Issue(store Store, name string, rateLimit, dailyQuota int) (Key, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Key{}, "", errors.New(missingNameError)
+	}
+	if rateLimit <= 0 {
+		return Key{}, "", errors.New(rateLimitError)
+	}
+	if dailyQuota < 0 {
+		return Key{}, "", errors.New(dailyQuotaError)
+	}
+
+	random, err := randomHex(24)
+	if err != nil {
+		return Key{}, "", err
+	}
+	secret := secretPrefix + random
+
+	key, err := store.Create(Key{
+		Name:       name,
+		Prefix:     secret[:len(secretPrefix)+6],
+		Hash:       hash(secret),
+		RateLimit:  rateLimit,
+		DailyQuota: dailyQuota,
+	})
+	if err != nil {
+		return Key{}, "", err
+	}
+
+	return key, secret, nil
+}
+
+// Authenticate returns the key of the secret, failing with ErrInvalidKey when
+// there is none and ErrRevoked when it was revoked.
+func Authenticate(store Store, secret string) (Key, error) {
+	if !strings.HasPrefix(secret, secretPrefix) {
+		return Key{}, ErrInvalidKey
+	}
+
+	key, err := store.FindByHash(hash(secret))
+	if errors.Is(err, ErrNotFound) {
+		return Key{}, ErrInvalidKey
+	}
+	if err != nil {
+		return Key{}, err
+	}
+	if key.Revoked() {
+		return Key{}, ErrRevoked
+	}
+
+	return key, nil
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(size int) (string, error) {
+	b := make([]byte, size)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,137 @@
+package apikey
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T, store Store) {
+	key, secret, err := Issue(store, " loja ", 10, 1000)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if key.ID == "" || key.Name != "loja" || key.CreatedAt.IsZero() || !strings.HasPrefix(secret, key.Prefix) {
+		t.Errorf("Issue() = %+v, %q, want ID, trimmed name, creation time and prefix of the secret", key, secret)
+	}
+	if strings.Contains(key.Hash, secret) || key.Hash == "" {
+		t.Errorf("Issue() hash = %q, want the hash of the secret", key.Hash)
+	}
+
+	got, err := Authenticate(store, secret)
+	if err != nil || got.ID != key.ID {
+		t.Errorf("Authenticate() = %+v, %v, want %s", got, err, key.ID)
+	}
+
+	_, err = Authenticate(store, secret+"0")
+	if !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Authenticate() with a wrong secret error = %v, want %v", err, ErrInvalidKey)
+	}
+
+	revoked, err := store.Revoke(key.ID)
+	if err != nil || !revoked.Revoked() {
+		t.Fatalf("Revoke() = %+v, %v, want a revoked key", revoked, err)
+	}
+	_, err = Authenticate(store, secret)
+	if !errors.Is(err, ErrRevoked) {
+		t.Errorf("Authenticate() after Revoke() error = %v, want %v", err, ErrRevoked)
+	}
+
+	_, err = store.Revoke("missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Revoke() of a missing key error = %v, want %v", err, ErrNotFound)
+	}
+
+	list, err := store.List()
+	if err != nil || len(list) != 1 {
+		t.Errorf("List() = %v, %v, want one key", list, err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	testStore(t, store)
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() reopening error = %v", err)
+	}
+	list, err := reopened.List()
+	if err != nil || len(list) != 1 || !list[0].Revoked() {
+		t.Errorf("List() after reopening = %v, %v, want the revoked key", list, err)
+	}
+}
+
+func TestIssue(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyName    string
+		rateLimit  int
+		dailyQuota int
+		wantErr    string
+	}{
+		{"Should_Issue_When_QuotaIsUnlimited", "loja", 10, 0, ""},
+		{"Should_Fail_When_NameIsEmpty", "  ", 10, 0, missingNameError},
+		{"Should_Fail_When_RateLimitIsZero", "loja", 0, 0, rateLimitError},
+		{"Should_Fail_When_QuotaIsNegative", "loja", 10, -1, dailyQuotaError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Issue(NewMemoryStore(), tt.keyName, tt.rateLimit, tt.dailyQuota)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Issue() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Issue() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2024, 3, 10, 23, 58, 0, 0, time.UTC)
+	limiter := NewLimiter(time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	key := Key{ID: "a", RateLimit: 2, DailyQuota: 3}
+
+	for i, want := range []Decision{
+		{Allowed: true, Limit: 2, Remaining: 1, Reset: now.Add(time.Minute)},
+		{Allowed: true, Limit: 2, Remaining: 0, Reset: now.Add(time.Minute)},
+		{Allowed: false, Limit: 2, Remaining: 0, Reset: now.Add(time.Minute)},
+	} {
+		got := limiter.Allow(key)
+		if got != want {
+			t.Errorf("Allow() #%d = %+v, want %+v", i+1, got, want)
+		}
+	}
+	if retry := limiter.Allow(key).RetryAfter(now); retry != time.Minute {
+		t.Errorf("RetryAfter() = %v, want %v", retry, time.Minute)
+	}
+
+	// The next window allows one more request before the daily quota runs out.
+	now = now.Add(time.Minute)
+	midnight := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	got := limiter.Allow(key)
+	if want := (Decision{Allowed: true, Limit: 3, Remaining: 0, Reset: midnight}); got != want {
+		t.Errorf("Allow() with one request left today = %+v, want %+v", got, want)
+	}
+
+	// A new day restores the quota.
+	now = now.Add(time.Minute)
+	got = limiter.Allow(key)
+	if !got.Allowed || got.Limit != 2 || got.Remaining != 1 {
+		t.Errorf("Allow() on the next day = %+v, want allowed with the rate limit", got)
+	}
+}
@@ -0,0 +1,109 @@
+package apikey
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fileStore keeps the keys in memory and rewrites the whole JSON file after
+// each change, replacing it atomically. The file holds hashes only, never
+// the secrets.
+type fileStore struct {
+	*memoryStore
+	path    string
+	writeMu sync.Mutex
+}
+
+func NewFileStore(path string) (Store, error) {
+	s := &fileStore{
+		memoryStore: newMemoryStore(),
+		path:        path,
+	}
+
+	err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *fileStore) Create(key Key) (Key, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	created, err := s.memoryStore.Create(key)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return created, s.save()
+}
+
+func (s *fileStore) Revoke(id string) (Key, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	revoked, err := s.memoryStore.Revoke(id)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return revoked, s.save()
+}
+
+func (s *fileStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var keys []Key
+	err = json.Unmarshal(data, &keys)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		s.keys[key.ID] = key
+	}
+
+	return nil
+}
+
+func (s *fileStore) save() error {
+	keys, err := s.memoryStore.List()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,91 @@
+package apikey
+
+import (
+	"sync"
+	"time"
+)
+
+// Decision is the outcome of counting one request against a key's limits.
+// Limit, Remaining and Reset describe whichever limit is closer to running
+// out, and are what the X-RateLimit-* headers report.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RetryAfter is how long the client has to wait before the request would be
+// allowed again.
+func (d Decision) RetryAfter(now time.Time) time.Duration {
+	if d.Allowed || !d.Reset.After(now) {
+		return 0
+	}
+	return d.Reset.Sub(now)
+}
+
+// Limiter counts requests per key in fixed windows of Window and per UTC day
+// for the daily quota. Counts are kept in memory, so they restart with the
+// server.
+type Limiter struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	counts map[string]*usage
+}
+
+type usage struct {
+	windowStart time.Time
+	inWindow    int
+	day         time.Time
+	inDay       int
+}
+
+func NewLimiter(window time.Duration) *Limiter {
+	return &Limiter{window: window, now: time.Now, counts: map[string]*usage{}}
+}
+
+// Allow counts a request of key unless one of its limits is exhausted. A
+// rejected request does not use up quota.
+func (l *Limiter) Allow(key Key) Decision {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	u, ok := l.counts[key.ID]
+	if !ok {
+		u = &usage{}
+		l.counts[key.ID] = u
+	}
+
+	windowStart := now.Truncate(l.window)
+	if !u.windowStart.Equal(windowStart) {
+		u.windowStart, u.inWindow = windowStart, 0
+	}
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !u.day.Equal(day) {
+		u.day, u.inDay = day, 0
+	}
+
+	rate := Decision{Limit: key.RateLimit, Remaining: key.RateLimit - u.inWindow, Reset: windowStart.Add(l.window)}
+	decision := rate
+	if key.DailyQuota > 0 {
+		quota := Decision{Limit: key.DailyQuota, Remaining: key.DailyQuota - u.inDay, Reset: day.Add(24 * time.Hour)}
+		if quota.Remaining < rate.Remaining || (quota.Remaining <= 0 && rate.Remaining <= 0) {
+			decision = quota
+		}
+	}
+
+	if decision.Remaining <= 0 {
+		decision.Remaining = 0
+		return decision
+	}
+
+	u.inWindow++
+	u.inDay++
+	decision.Allowed = true
+	decision.Remaining--
+	return decision
+}
@@ -0,0 +1,90 @@
+package apikey
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu   sync.RWMutex
+	keys map[string]Key
+	now  func() time.Time
+}
+
+func NewMemoryStore() Store {
+	return newMemoryStore()
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		keys: map[string]Key{},
+		now:  time.Now,
+	}
+}
+
+func (s *memoryStore) Create(key Key) (Key, error) {
+	id, err := randomHex(8)
+	if err != nil {
+		return Key{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key.ID = id
+	key.CreatedAt = s.now().UTC()
+	key.RevokedAt = nil
+	s.keys[id] = key
+
+	return key, nil
+}
+
+func (s *memoryStore) List() ([]Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func (s *memoryStore) FindByHash(hash string) (Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+	return Key{}, ErrNotFound
+}
+
+// Revoke marks the key as revoked. Revoking a key twice keeps the first
+// revocation time.
+func (s *memoryStore) Revoke(id string) (Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return Key{}, ErrNotFound
+	}
+
+	if key.RevokedAt == nil {
+		now := s.now().UTC()
+		key.RevokedAt = &now
+		s.keys[id] = key
+	}
+	return key, nil
+}
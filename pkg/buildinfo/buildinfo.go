@@ -0,0 +1,49 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Commit and BuildTime are set at build time, e.g.
+//
+//	go build -ldflags "-X digitalrepublic/pkg/buildinfo.Commit=$(git rev-parse HEAD)
+//	  -X digitalrepublic/pkg/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd
+//
+// When they are not, the version control details embedded by the Go
+// toolchain are used instead, if any.
+var (
+	Commit    string
+	BuildTime string
+)
+
+const unknown = "unknown"
+
+type Info struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+func Get() Info {
+	info := Info{Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildTime == "":
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = unknown
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = unknown
+	}
+	return info
+}
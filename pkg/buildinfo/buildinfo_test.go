@@ -0,0 +1,16 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	Commit, BuildTime = "abc123", "2026-10-19T12:00:00Z"
+	defer func() { Commit, BuildTime = "", "" }()
+
+	want := Info{Commit: "abc123", BuildTime: "2026-10-19T12:00:00Z", GoVersion: runtime.Version()}
+	if got := Get(); got != want {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+}
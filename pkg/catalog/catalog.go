@@ -0,0 +1,55 @@
+package catalog
+
+import (
+	"digitalrepublic/pkg/entities"
+	"fmt"
+	"strings"
+)
+
+// Cents is an amount of money in hundredths of a real.
+type Cents int64
+
+// Catalog holds the price of each can size. Wall paint and enamel are priced
+// apart because both are sold in 3.6L cans.
+type Catalog struct {
+	Currency  string
+	WallPaint map[entities.Can]Cents
+	Enamel    map[entities.Can]Cents
+}
+
+func Default() Catalog {
+	return Catalog{
+		Currency: "R$",
+		WallPaint: map[entities.Can]Cents{
+			entities.HugeCan:   38990,
+			entities.BigCan:    9990,
+			entities.MediumCan: 7490,
+			entities.SmallCan:  2490,
+		},
+		Enamel: map[entities.Can]Cents{
+			entities.EnamelBigCan:    14990,
+			entities.EnamelMediumCan: 4990,
+			entities.EnamelSmallCan:  1990,
+		},
+	}
+}
+
+// Format writes the amount the Brazilian way, e.g. "R$ 1.234,56".
+func (c Catalog) Format(amount Cents) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	units := fmt.Sprintf("%d", amount/100)
+	var grouped strings.Builder
+	for i, digit := range units {
+		if i > 0 && (len(units)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return fmt.Sprintf("%s%s %s,%02d", sign, c.Currency, grouped.String(), amount%100)
+}
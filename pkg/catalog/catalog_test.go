@@ -0,0 +1,26 @@
+package catalog
+
+import "testing"
+
+func TestCatalog_Format(t *testing.T) {
+	type args struct {
+		amount Cents
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "Should_ReturnFormatedAmount_When_Cents", args: args{amount: 5}, want: "R$ 0,05"},
+		{name: "Should_ReturnFormatedAmount_When_Hundreds", args: args{amount: 38990}, want: "R$ 389,90"},
+		{name: "Should_GroupThousands_When_Thousands", args: args{amount: 123456789}, want: "R$ 1.234.567,89"},
+		{name: "Should_ReturnNegativeAmount_When_Negative", args: args{amount: -250}, want: "-R$ 2,50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Default().Format(tt.args.amount); got != tt.want {
+				t.Errorf("Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+// Package client calls the Paint Calculator API from Go, with typed requests
+// and responses, retries on rate limits and API errors decoded as *Error.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultMaxWait    = time.Minute
+	baseBackoff       = 500 * time.Millisecond
+	problemType       = "application/problem+json"
+)
+
+// Client calls one Paint Calculator server. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	apiKey     string
+	adminToken string
+	maxRetries int
+	maxWait    time.Duration
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for the requests, e.g. to set a
+// timeout or a transport. http.DefaultClient is used otherwise.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIKey sends the key in X-API-Key, for servers that require one.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithAdminToken sets the token sent to the /admin routes.
+func WithAdminToken(token string) Option {
+	return func(c *Client) {
+		c.adminToken = token
+	}
+}
+
+// WithRetries sets how many times a request answered with 429 is retried,
+// and the longest wait accepted before a retry. A server asking to wait
+// longer than maxWait fails the request right away.
+func WithRetries(maxRetries int, maxWait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.maxWait = maxWait
+	}
+}
+
+// New returns a client of the server at baseURL, e.g. http://localhost:8080.
+func New(baseURL string, options ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("client: base URL must be absolute, got %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    parsed,
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		maxWait:    defaultMaxWait,
+		sleep:      sleep,
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	return c, nil
+}
+
+// request is one call to the API. body is sent as is with contentType; the
+// response is decoded into out when it is not nil.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+	accept      string
+	admin       bool
+}
+
+func jsonRequest(method, path string, in interface{}) (request, error) {
+	r := request{method: method, path: path}
+	if in == nil {
+		return r, nil
+	}
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return r, err
+	}
+	r.body, r.contentType = body, "application/json"
+	return r, nil
+}
+
+// doJSON sends r and decodes the JSON response into out.
+func (c *Client) doJSON(ctx context.Context, r request, out interface{}) error {
+	r.accept = "application/json"
+	data, err := c.do(ctx, r)
+	if err != nil || out == nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+// do sends r, retrying while the server answers 429, and returns the body of
+// the first successful response.
+func (c *Client) do(ctx context.Context, r request) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := c.send(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode < 300 {
+			return data, nil
+		}
+
+		apiErr := decodeError(response, data)
+		if response.StatusCode != http.StatusTooManyRequests || attempt >= c.maxRetries {
+			return nil, apiErr
+		}
+
+		wait := retryAfter(response.Header.Get("Retry-After"), attempt, time.Now())
+		if wait > c.maxWait {
+			return nil, apiErr
+		}
+		err = c.sleep(ctx, wait)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	target := *c.baseURL
+	target.Path += r.path
+	target.RawQuery = r.query.Encode()
+
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	if r.accept != "" {
+		req.Header.Set("Accept", r.accept)
+	}
+	if r.admin && c.adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+	if !r.admin && c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// retryAfter reads the Retry-After header, given in seconds or as an HTTP
+// date. Without it the wait doubles on every attempt.
+func retryAfter(header string, attempt int, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return baseBackoff * time.Duration(math.Pow(2, float64(attempt)))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Error is an error answered by the API, decoded from its
+// application/problem+json body.
+type Error struct {
+	Status    int          `json:"status"`
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Detail    string       `json:"detail"`
+	Instance  string       `json:"instance"`
+	RequestID string       `json:"request_id"`
+	Errors    []FieldError `json:"errors"`
+}
+
+// FieldError is one invalid field of the request: a JSON pointer into the
+// body, such as /walls/2/door_quantity, or a row and column of an imported
+// file.
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Row     int    `json:"row"`
+	Column  int    `json:"column"`
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Detail  string `json:"detail"`
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("paint calculator: %d %s", e.Status, e.Title)
+	if e.Detail != "" {
+		message += ": " + e.Detail
+	}
+	for _, field := range e.Errors {
+		if field.Pointer != "" {
+			message += fmt.Sprintf("; %s: %s", field.Pointer, field.Detail)
+		}
+	}
+	return message
+}
+
+// Code returns the code of the first invalid field, e.g. wall_area_limit, or
+// an empty string when there is none.
+func (e *Error) Code() string {
+	for _, field := range e.Errors {
+		if field.Code != "" {
+			return field.Code
+		}
+	}
+	return ""
+}
+
+// StatusCode returns the HTTP status of err when it is an *Error, and 0
+// otherwise.
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+	return 0
+}
+
+// decodeError builds the error of a failed response. Bodies that are not
+// problems, e.g. from a proxy, are kept as the detail.
+func decodeError(response *http.Response, data []byte) *Error {
+	apiErr := &Error{}
+	if strings.HasPrefix(response.Header.Get("Content-Type"), problemType) {
+		_ = json.Unmarshal(data, apiErr)
+	} else {
+		apiErr.Detail = strings.TrimSpace(string(data))
+	}
+
+	apiErr.Status = response.StatusCode
+	if apiErr.Title == "" {
+		apiErr.Title = http.StatusText(response.StatusCode)
+	}
+	return apiErr
+}
@@ -0,0 +1,316 @@
+package client
+
+import (
+	"context"
+	"digitalrepublic/api/handlers"
+	"digitalrepublic/api/routes"
+	"digitalrepublic/pkg/apikey"
+	"digitalrepublic/pkg/catalog"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const adminToken = "segredo"
+
+// newServer runs the API routes on a random port and returns its base URL.
+func newServer(t *testing.T) string {
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler, DisableStartupMessage: true})
+	// Proxies may drop the body of a GET, so the client must never send one.
+	app.Use(func(c *fiber.Ctx) error {
+		if c.Method() == fiber.MethodGet && len(c.Body()) > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "GET with a body")
+		}
+		return c.Next()
+	})
+	routes.Router(app.Group("/api/v1"), service.New(paint.NewCalculateRoomPaintInCans(), estimate.NewMemoryRepository()), catalog.Default())
+	routes.Admin(app.Group("/admin", handlers.RequireAdminToken(adminToken)), apikey.NewMemoryStore(), 100)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	go app.Listener(listener)
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	return "http://" + listener.Addr().String()
+}
+
+var room = paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{
+	{Width: 5, Height: 2.5, DoorQuantity: 1, WindowQuantity: 1},
+	{Width: 4, Height: 2.5},
+}}
+
+func TestClient_Paint(t *testing.T) {
+	c, err := New(newServer(t))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := c.AmountOfPaint(ctx, room)
+	if err != nil {
+		t.Fatalf("AmountOfPaint() error = %v", err)
+	}
+	want, _ := paint.NewCalculateRoomPaintInCans().Execute(context.Background(), room)
+	if result.Liters != want.Liters || result.LargeCan != want.LargeCan || len(result.Walls) != 2 {
+		t.Errorf("AmountOfPaint() = %+v, want %+v", result, want)
+	}
+
+	csv, err := c.ExportAmountOfPaint(ctx, room, "csv")
+	if err != nil || !strings.HasPrefix(string(csv), "type,item,area") {
+		t.Errorf("ExportAmountOfPaint() = %q, %v, want a CSV", csv, err)
+	}
+
+	invalid := paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{room.Walls[0], {Width: 5, Height: 2.5, DoorQuantity: -1}}}
+	_, err = c.AmountOfPaint(ctx, invalid)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("AmountOfPaint() error = %v, want *Error", err)
+	}
+	if apiErr.Status != http.StatusBadRequest || apiErr.Code() != paint.CodeDoorNegative ||
+		len(apiErr.Errors) != 1 || apiErr.Errors[0].Pointer != "/walls/1/door_quantity" {
+		t.Errorf("AmountOfPaint() error = %+v, want door_negative at /walls/1/door_quantity", apiErr)
+	}
+
+	results, err := c.Batch(ctx, []paint.BatchItem{{ID: "sala", CalculateRoomPaintInCansInput: room}, {ID: "ruim", CalculateRoomPaintInCansInput: invalid}})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if results["sala"].Result == nil || results["ruim"].Error == "" {
+		t.Errorf("Batch() = %+v, want a result for sala and an error for ruim", results)
+	}
+
+	imported, err := c.ImportWalls(ctx, "paredes.csv", strings.NewReader("room,wall,width,height,doors,windows\nsala,1,5,2.5,1,1\n"))
+	if err != nil || len(imported) != 1 || imported[0].Name != "sala" || imported[0].Result == nil {
+		t.Errorf("ImportWalls() = %+v, %v, want the room sala calculated", imported, err)
+	}
+}
+
+func TestClient_Estimates(t *testing.T) {
+	c, err := New(newServer(t))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	created, err := c.CreateEstimate(ctx, room)
+	if err != nil {
+		t.Fatalf("CreateEstimate() error = %v", err)
+	}
+
+	changed := paint.CalculateRoomPaintInCansInput{Walls: room.Walls[:1]}
+	updated, err := c.UpdateEstimate(ctx, created.ID, changed)
+	if err != nil || len(updated.Revisions) != 2 {
+		t.Fatalf("UpdateEstimate() = %+v, %v, want two revisions", updated, err)
+	}
+
+	revision, err := c.GetRevision(ctx, created.ID, 1)
+	if err != nil || len(revision.Input.Walls) != 2 {
+		t.Errorf("GetRevision() = %+v, %v, want the first room", revision, err)
+	}
+
+	diff, err := c.DiffRevisions(ctx, created.ID, 0, 0)
+	if err != nil || diff.From != 1 || diff.To != 2 || len(diff.WallsRemoved) != 1 {
+		t.Errorf("DiffRevisions() = %+v, %v, want one wall removed from 1 to 2", diff, err)
+	}
+
+	exported, err := c.ExportEstimate(ctx, created.ID, 1, "csv")
+	if err != nil || !strings.HasPrefix(string(exported), "type,") {
+		t.Errorf("ExportEstimate() = %q, %v, want a CSV", exported, err)
+	}
+
+	list, err := c.ListEstimates(ctx)
+	if err != nil || len(list) != 1 {
+		t.Errorf("ListEstimates() = %v, %v, want one estimate", list, err)
+	}
+
+	err = c.DeleteEstimate(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("DeleteEstimate() error = %v", err)
+	}
+	_, err = c.GetEstimate(ctx, created.ID)
+	if StatusCode(err) != http.StatusNotFound {
+		t.Errorf("GetEstimate() after DeleteEstimate() error = %v, want 404", err)
+	}
+}
+
+func TestClient_Keys(t *testing.T) {
+	baseURL := newServer(t)
+	ctx := context.Background()
+
+	unauthorized, _ := New(baseURL)
+	_, err := unauthorized.ListKeys(ctx)
+	if StatusCode(err) != http.StatusUnauthorized {
+		t.Errorf("ListKeys() without token error = %v, want 401", err)
+	}
+
+	c, _ := New(baseURL, WithAdminToken(adminToken))
+	key, secret, err := c.IssueKey(ctx, "loja", 0, 500)
+	if err != nil || !strings.HasPrefix(secret, key.Prefix) || key.RateLimit != 100 || key.DailyQuota != 500 {
+		t.Fatalf("IssueKey() = %+v, %q, %v, want the default rate limit and the quota", key, secret, err)
+	}
+
+	revoked, err := c.RevokeKey(ctx, key.ID)
+	if err != nil || !revoked.Revoked() {
+		t.Errorf("RevokeKey() = %+v, %v, want a revoked key", revoked, err)
+	}
+
+	keys, err := c.ListKeys(ctx)
+	if err != nil || len(keys) != 1 {
+		t.Errorf("ListKeys() = %v, %v, want one key", keys, err)
+	}
+}
+
+// rateLimited answers 429 with the Retry-After header the first times times,
+// then succeeds.
+func rateLimited(times int32, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "pk_teste" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if calls.Add(1) <= times {
+			w.Header().Set("Retry-After", retryAfter)
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"type": "about:blank", "title": "Too Many Requests", "status": 429, "request_id": "abc"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"big_can": 1, "liters": 3}`))
+	}))
+	return server, &calls
+}
+
+func TestClient_Retries(t *testing.T) {
+	tests := []struct {
+		name       string
+		limited    int32
+		retryAfter string
+		options    []Option
+		wantCalls  int32
+		wantWaits  []time.Duration
+		wantStatus int
+	}{
+		{
+			name:       "Should_RetryAfterTheGivenSeconds_When_RateLimited",
+			limited:    2,
+			retryAfter: "2",
+			wantCalls:  3,
+			wantWaits:  []time.Duration{2 * time.Second, 2 * time.Second},
+		},
+		{
+			name:       "Should_ReturnError_When_RetriesRunOut",
+			limited:    5,
+			retryAfter: "1",
+			options:    []Option{WithRetries(1, time.Minute)},
+			wantCalls:  2,
+			wantWaits:  []time.Duration{time.Second},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "Should_ReturnError_When_WaitIsTooLong",
+			limited:    1,
+			retryAfter: "120",
+			wantCalls:  1,
+			wantStatus: http.StatusTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := rateLimited(tt.limited, tt.retryAfter)
+			defer server.Close()
+
+			c, err := New(server.URL, append([]Option{WithAPIKey("pk_teste")}, tt.options...)...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			var waits []time.Duration
+			c.sleep = func(ctx context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			result, err := c.AmountOfPaint(context.Background(), room)
+			if StatusCode(err) != tt.wantStatus {
+				t.Fatalf("AmountOfPaint() error = %v, want status %d", err, tt.wantStatus)
+			}
+			if tt.wantStatus == 0 && (result == nil || result.LargeCan != 1) {
+				t.Errorf("AmountOfPaint() = %+v, want the result after retrying", result)
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls.Load(), tt.wantCalls)
+			}
+			if len(waits) != len(tt.wantWaits) {
+				t.Fatalf("waits = %v, want %v", waits, tt.wantWaits)
+			}
+			for i := range waits {
+				if waits[i] != tt.wantWaits[i] {
+					t.Errorf("waits = %v, want %v", waits, tt.wantWaits)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_CancelWhileWaiting(t *testing.T) {
+	server, calls := rateLimited(5, "30")
+	defer server.Close()
+
+	c, _ := New(server.URL, WithAPIKey("pk_teste"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.AmountOfPaint(ctx, room)
+	if !errors.Is(err, context.DeadlineExceeded) || calls.Load() != 1 {
+		t.Errorf("AmountOfPaint() error = %v after %d calls, want the deadline after one call", err, calls.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{"Should_UseSeconds_When_HeaderIsANumber", "3", 0, 3 * time.Second},
+		{"Should_UseDate_When_HeaderIsAnHTTPDate", "Mon, 19 Oct 2026 12:00:10 GMT", 0, 10 * time.Second},
+		{"Should_NotWait_When_DateHasPassed", "Mon, 19 Oct 2026 11:59:00 GMT", 0, 0},
+		{"Should_BackOff_When_HeaderIsMissing", "", 2, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryAfter(tt.header, tt.attempt, now); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError_WhenBodyIsNotAProblem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL)
+	_, err := c.ListEstimates(context.Background())
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadGateway || apiErr.Detail != "bad gateway" || apiErr.Title != "Bad Gateway" {
+		t.Errorf("ListEstimates() error = %#v, want 502 with the body as detail", err)
+	}
+}
@@ -0,0 +1,265 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"digitalrepublic/pkg/apikey"
+	"digitalrepublic/pkg/buildinfo"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/importer"
+	"digitalrepublic/pkg/paint"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const apiPrefix = "/api/v1"
+
+// AmountOfPaint calculates the cans needed to paint the room.
+func (c *Client) AmountOfPaint(ctx context.Context, room paint.CalculateRoomPaintInCansInput) (*paint.CalculateRoomPaintInCansOutput, error) {
+	r, err := jsonRequest(http.MethodPost, apiPrefix+"/amount-of-paint", room)
+	if err != nil {
+		return nil, err
+	}
+
+	var result paint.CalculateRoomPaintInCansOutput
+	err = c.doJSON(ctx, r, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExportAmountOfPaint calculates the room and returns the result as a file in
+// format: json, csv or xlsx.
+func (c *Client) ExportAmountOfPaint(ctx context.Context, room paint.CalculateRoomPaintInCansInput, format string) ([]byte, error) {
+	r, err := jsonRequest(http.MethodPost, apiPrefix+"/amount-of-paint", room)
+	if err != nil {
+		return nil, err
+	}
+	r.query = url.Values{"format": {format}}
+
+	return c.do(ctx, r)
+}
+
+// Batch calculates many rooms at once. Rooms that cannot be calculated have
+// their Error set instead of failing the whole batch.
+func (c *Client) Batch(ctx context.Context, rooms []paint.BatchItem) (map[string]paint.BatchResult, error) {
+	r, err := jsonRequest(http.MethodPost, apiPrefix+"/batch", map[string]interface{}{"rooms": rooms})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Results map[string]paint.BatchResult `json:"results"`
+	}
+	err = c.doJSON(ctx, r, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
+
+func (c *Client) CreateEstimate(ctx context.Context, room paint.CalculateRoomPaintInCansInput) (*estimate.Estimate, error) {
+	r, err := jsonRequest(http.MethodPost, apiPrefix+"/estimates", room)
+	if err != nil {
+		return nil, err
+	}
+
+	var created estimate.Estimate
+	err = c.doJSON(ctx, r, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *Client) ListEstimates(ctx context.Context) ([]estimate.Estimate, error) {
+	var estimates []estimate.Estimate
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: apiPrefix + "/estimates"}, &estimates)
+	return estimates, err
+}
+
+func (c *Client) GetEstimate(ctx context.Context, id string) (*estimate.Estimate, error) {
+	var found estimate.Estimate
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: estimatePath(id)}, &found)
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// UpdateEstimate replaces the room of the estimate, adding a revision.
+func (c *Client) UpdateEstimate(ctx context.Context, id string, room paint.CalculateRoomPaintInCansInput) (*estimate.Estimate, error) {
+	r, err := jsonRequest(http.MethodPut, estimatePath(id), room)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated estimate.Estimate
+	err = c.doJSON(ctx, r, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *Client) DeleteEstimate(ctx context.Context, id string) error {
+	return c.doJSON(ctx, request{method: http.MethodDelete, path: estimatePath(id)}, nil)
+}
+
+func (c *Client) ListRevisions(ctx context.Context, id string) ([]estimate.Revision, error) {
+	var revisions []estimate.Revision
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: estimatePath(id) + "/revisions"}, &revisions)
+	return revisions, err
+}
+
+func (c *Client) GetRevision(ctx context.Context, id string, number int) (*estimate.Revision, error) {
+	var revision estimate.Revision
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: estimatePath(id) + "/revisions/" + strconv.Itoa(number)}, &revision)
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// DiffRevisions compares two revisions of the estimate. A from or to of 0 is
+// left out of the request, so the server picks it: the latest revision for to
+// and the one before to for from. Other numbers below 1 are rejected.
+func (c *Client) DiffRevisions(ctx context.Context, id string, from, to int) (*estimate.RevisionDiff, error) {
+	query := url.Values{}
+	if from != 0 {
+		query.Set("from", strconv.Itoa(from))
+	}
+	if to != 0 {
+		query.Set("to", strconv.Itoa(to))
+	}
+
+	var diff estimate.RevisionDiff
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: estimatePath(id) + "/diff", query: query}, &diff)
+	if err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// Quote returns the PDF quote of a revision of the estimate; revision 0 is
+// the latest.
+func (c *Client) Quote(ctx context.Context, id string, revision int) ([]byte, error) {
+	return c.do(ctx, request{method: http.MethodGet, path: estimatePath(id) + "/quote.pdf", query: revisionQuery(revision)})
+}
+
+// ExportEstimate returns the result of a revision of the estimate as a file
+// in format: json, csv or xlsx. Revision 0 is the latest.
+func (c *Client) ExportEstimate(ctx context.Context, id string, revision int, format string) ([]byte, error) {
+	query := revisionQuery(revision)
+	query.Set("format", format)
+
+	return c.do(ctx, request{method: http.MethodGet, path: estimatePath(id) + "/export", query: query})
+}
+
+// ImportedRoom is a room read from an imported file, already calculated.
+type ImportedRoom struct {
+	importer.Room
+	Result *paint.CalculateRoomPaintInCansOutput `json:"result"`
+}
+
+// ImportWalls uploads a CSV or XLSX wall list, the format being taken from
+// the extension of filename, and returns its rooms calculated.
+func (c *Client) ImportWalls(ctx context.Context, filename string, file io.Reader) ([]ImportedRoom, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	part, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.Copy(part, file)
+	if err != nil {
+		return nil, err
+	}
+	err = form.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Rooms []ImportedRoom `json:"rooms"`
+	}
+	r := request{method: http.MethodPost, path: apiPrefix + "/imports", body: body.Bytes(), contentType: form.FormDataContentType()}
+	err = c.doJSON(ctx, r, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Rooms, nil
+}
+
+// IssueKey creates an API key and returns it with its secret, which the
+// server does not show again. A rateLimit of 0 uses the server default and a
+// dailyQuota of 0 is unlimited. It needs WithAdminToken.
+func (c *Client) IssueKey(ctx context.Context, name string, rateLimit, dailyQuota int) (*apikey.Key, string, error) {
+	body := map[string]interface{}{"name": name, "daily_quota": dailyQuota}
+	if rateLimit > 0 {
+		body["rate_limit"] = rateLimit
+	}
+	r, err := jsonRequest(http.MethodPost, "/admin/keys", body)
+	if err != nil {
+		return nil, "", err
+	}
+	r.admin = true
+
+	var response struct {
+		Key    apikey.Key `json:"key"`
+		Secret string     `json:"secret"`
+	}
+	err = c.doJSON(ctx, r, &response)
+	if err != nil {
+		return nil, "", err
+	}
+	return &response.Key, response.Secret, nil
+}
+
+// ListKeys lists the API keys. It needs WithAdminToken.
+func (c *Client) ListKeys(ctx context.Context) ([]apikey.Key, error) {
+	var keys []apikey.Key
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/admin/keys", admin: true}, &keys)
+	return keys, err
+}
+
+// RevokeKey revokes an API key. It needs WithAdminToken.
+func (c *Client) RevokeKey(ctx context.Context, id string) (*apikey.Key, error) {
+	var key apikey.Key
+	err := c.doJSON(ctx, request{method: http.MethodDelete, path: "/admin/keys/" + url.PathEscape(id), admin: true}, &key)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Ready returns nil when the server is ready to take traffic.
+func (c *Client) Ready(ctx context.Context) error {
+	return c.doJSON(ctx, request{method: http.MethodGet, path: "/readyz"}, nil)
+}
+
+func (c *Client) Version(ctx context.Context) (*buildinfo.Info, error) {
+	var info buildinfo.Info
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/version"}, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func estimatePath(id string) string {
+	return apiPrefix + "/estimates/" + url.PathEscape(id)
+}
+
+func revisionQuery(revision int) url.Values {
+	query := url.Values{}
+	if revision != 0 {
+		query.Set("revision", strconv.Itoa(revision))
+	}
+	return query
+}
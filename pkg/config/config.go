@@ -0,0 +1,361 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	invalidConfigError    = "configuração invalida"
+	unknownSettingError   = "configuração desconhecida"
+	invalidIntegerError   = "valor inteiro invalido"
+	invalidBooleanError   = "valor booleano invalido, use true ou false"
+	invalidDurationError  = "duração invalida, use por exemplo 10s ou 1m"
+	invalidFileValueError = "valor invalido no arquivo de configuração"
+	appNameError          = "app_name não pode ser vazio"
+	addressError          = "address deve estar no formato host:porta, ex. :8080"
+	rateLimitError        = "rate_limit deve ser maior que 0"
+	rateLimitWindowError  = "rate_limit_window deve ser maior que 0"
+	corsOriginsError      = "cors_origins deve ter * ou origens como https://exemplo.com.br"
+	bodyLimitError        = "body_limit deve ser maior que 0"
+	timeoutError          = "os timeouts não podem ser negativos"
+	shutdownTimeoutError  = "shutdown_timeout deve ser maior que 0"
+	invalidLogLevelError  = "nível de log invalido, use debug, info, warn ou error"
+)
+
+// EnvPrefix prefixes the environment variable of every setting, e.g.
+// PAINT_ADDRESS for address.
+const EnvPrefix = "PAINT_"
+
+// Config holds the settings of the API server. Durations of zero disable the
+// corresponding timeout.
+type Config struct {
+	AppName         string
+	Address         string
+	RateLimit       int
+	RateLimitWindow time.Duration
+	CORSOrigins     []string
+	BodyLimit       int
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	// ShutdownTimeout is how long in-flight requests have to finish once the
+	// server is asked to stop.
+	ShutdownTimeout time.Duration
+	LogLevel        slog.Level
+	// EstimatesFile is where estimates are saved; empty keeps them in memory.
+	EstimatesFile string
+	// RequireAPIKey protects /api/v1 with API keys, each with its own rate
+	// limit and daily quota, instead of the per-client rate limit.
+	RequireAPIKey bool
+	// APIKeysFile is where API keys are saved; empty keeps them in memory.
+	APIKeysFile string
+	// AdminToken enables the routes that issue and revoke API keys; empty
+	// leaves them disabled.
+	AdminToken string
+}
+
+func Default() Config {
+	return Config{
+		AppName:         "Paint Calculator!",
+		Address:         ":8080",
+		RateLimit:       100,
+		RateLimitWindow: time.Minute,
+		CORSOrigins:     []string{"*"},
+		BodyLimit:       4 * 1024 * 1024,
+		ReadTimeout:     10 * time.Second,
+		WriteTimeout:    30 * time.Second,
+		IdleTimeout:     time.Minute,
+		ShutdownTimeout: 10 * time.Second,
+		LogLevel:        slog.LevelInfo,
+	}
+}
+
+// setting is one configurable value. name is the flag; the file key and the
+// environment variable are derived from it.
+type setting struct {
+	name  string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+func (s setting) key() string {
+	return strings.ReplaceAll(s.name, "-", "_")
+}
+
+func (s setting) env() string {
+	return EnvPrefix + strings.ToUpper(s.key())
+}
+
+var settings = []setting{
+	{"app-name", "nome da aplicação", func(c *Config, v string) error {
+		c.AppName = v
+		return nil
+	}},
+	{"address", "endereço em que o servidor escuta, ex. :8080", func(c *Config, v string) error {
+		c.Address = v
+		return nil
+	}},
+	{"rate-limit", "requisições permitidas por cliente a cada janela", func(c *Config, v string) error {
+		return parseInt(v, &c.RateLimit)
+	}},
+	{"rate-limit-window", "janela do limite de requisições, ex. 1m", func(c *Config, v string) error {
+		return parseDuration(v, &c.RateLimitWindow)
+	}},
+	{"cors-origins", "origens permitidas separadas por vírgula, ou *", func(c *Config, v string) error {
+		c.CORSOrigins = splitList(v)
+		return nil
+	}},
+	{"body-limit", "tamanho máximo do corpo da requisição em bytes", func(c *Config, v string) error {
+		return parseInt(v, &c.BodyLimit)
+	}},
+	{"read-timeout", "tempo máximo para ler a requisição", func(c *Config, v string) error {
+		return parseDuration(v, &c.ReadTimeout)
+	}},
+	{"write-timeout", "tempo máximo para escrever a resposta", func(c *Config, v string) error {
+		return parseDuration(v, &c.WriteTimeout)
+	}},
+	{"idle-timeout", "tempo máximo de uma conexão ociosa", func(c *Config, v string) error {
+		return parseDuration(v, &c.IdleTimeout)
+	}},
+	{"shutdown-timeout", "tempo para concluir as requisições em andamento ao desligar", func(c *Config, v string) error {
+		return parseDuration(v, &c.ShutdownTimeout)
+	}},
+	{"log-level", "nível de log: debug, info, warn ou error", func(c *Config, v string) error {
+		err := c.LogLevel.UnmarshalText([]byte(v))
+		if err != nil {
+			return errors.New(invalidLogLevelError)
+		}
+		return nil
+	}},
+	{"estimates-file", "arquivo JSON em que os orçamentos são salvos; vazio mantém em memória", func(c *Config, v string) error {
+		c.EstimatesFile = v
+		return nil
+	}},
+	{"require-api-key", "exige uma chave de API em /api/v1", func(c *Config, v string) error {
+		return parseBool(v, &c.RequireAPIKey)
+	}},
+	{"api-keys-file", "arquivo JSON em que as chaves de API são salvas; vazio mantém em memória", func(c *Config, v string) error {
+		c.APIKeysFile = v
+		return nil
+	}},
+	{"admin-token", "token das rotas de administração de chaves; vazio as desativa", func(c *Config, v string) error {
+		c.AdminToken = v
+		return nil
+	}},
+}
+
+// Load builds the configuration from, in increasing priority, the defaults,
+// the JSON file given by -config or PAINT_CONFIG, the environment and the
+// command line flags, and validates the result.
+func Load(args []string, getenv func(string) string, output io.Writer) (Config, error) {
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	path := flags.String("config", getenv(EnvPrefix+"CONFIG"), "arquivo JSON de configuração")
+	overrides := map[string]string{}
+	for _, s := range settings {
+		name := s.name
+		flags.Func(name, s.usage+" ("+s.env()+")", func(value string) error {
+			overrides[name] = value
+			return nil
+		})
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return Config{}, err
+	}
+
+	c := Default()
+	if *path != "" {
+		err = c.loadFile(*path)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, s := range settings {
+		if value := getenv(s.env()); value != "" {
+			err = s.apply(&c, value, s.env())
+			if err != nil {
+				return Config{}, err
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := overrides[s.name]; ok {
+			err = s.apply(&c, value, "-"+s.name)
+			if err != nil {
+				return Config{}, err
+			}
+		}
+	}
+
+	return c, c.Validate()
+}
+
+func (s setting) apply(c *Config, value, source string) error {
+	err := s.set(c, strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	return nil
+}
+
+// loadFile applies the settings of a JSON object whose keys are the setting
+// names with underscores, e.g. {"address": ":9090", "read_timeout": "5s"}.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var values map[string]interface{}
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for key, value := range values {
+		s, ok := findSetting(key)
+		if !ok {
+			return fmt.Errorf("%s: %s: %s", path, unknownSettingError, key)
+		}
+
+		text, err := fileValue(value)
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", path, key, err)
+		}
+		err = s.apply(c, text, path+": "+key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findSetting(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key() == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// fileValue turns a JSON value into the text accepted by the flags. Lists are
+// joined with commas.
+func fileValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return "", errors.New(invalidFileValueError)
+			}
+			items[i] = text
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", errors.New(invalidFileValueError)
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var problems []string
+	check := func(ok bool, message string) {
+		if !ok {
+			problems = append(problems, message)
+		}
+	}
+
+	check(strings.TrimSpace(c.AppName) != "", appNameError)
+	_, port, err := net.SplitHostPort(c.Address)
+	check(err == nil && port != "", addressError)
+	check(c.RateLimit > 0, rateLimitError)
+	check(c.RateLimitWindow > 0, rateLimitWindowError)
+	check(validOrigins(c.CORSOrigins), corsOriginsError)
+	check(c.BodyLimit > 0, bodyLimitError)
+	check(c.ReadTimeout >= 0 && c.WriteTimeout >= 0 && c.IdleTimeout >= 0, timeoutError)
+	check(c.ShutdownTimeout > 0, shutdownTimeoutError)
+
+	if len(problems) > 0 {
+		return errors.New(invalidConfigError + ": " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validOrigins(origins []string) bool {
+	if len(origins) == 0 {
+		return false
+	}
+
+	for _, origin := range origins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return false
+		}
+	}
+	return true
+}
+
+func parseInt(value string, target *int) error {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return errors.New(invalidIntegerError)
+	}
+	*target = number
+	return nil
+}
+
+func parseBool(value string, target *bool) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return errors.New(invalidBooleanError)
+	}
+	*target = parsed
+	return nil
+}
+
+func parseDuration(value string, target *time.Duration) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return errors.New(invalidDurationError)
+	}
+	*target = duration
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
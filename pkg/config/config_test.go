@@ -0,0 +1,160 @@
+package config
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	file := writeFile(t, `{"address": ":9090", "rate_limit": 50, "cors_origins": ["https://loja.com.br"], "read_timeout": "5s"}`)
+
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		want    func(c *Config)
+		wantErr bool
+	}{
+		{
+			name: "Should_ReturnDefaults_When_NothingIsSet",
+			want: func(c *Config) {},
+		},
+		{
+			name: "Should_ReadFile_When_ConfigFlagIsSet",
+			args: []string{"-config", file},
+			want: func(c *Config) {
+				c.Address = ":9090"
+				c.RateLimit = 50
+				c.CORSOrigins = []string{"https://loja.com.br"}
+				c.ReadTimeout = 5 * time.Second
+			},
+		},
+		{
+			name: "Should_OverrideFile_When_EnvIsSet",
+			env:  map[string]string{"PAINT_CONFIG": file, "PAINT_RATE_LIMIT": "10", "PAINT_CORS_ORIGINS": "http://a.com, http://b.com"},
+			want: func(c *Config) {
+				c.Address = ":9090"
+				c.RateLimit = 10
+				c.CORSOrigins = []string{"http://a.com", "http://b.com"}
+				c.ReadTimeout = 5 * time.Second
+			},
+		},
+		{
+			name: "Should_OverrideEnv_When_FlagIsSet",
+			args: []string{"-rate-limit", "20", "-write-timeout", "0s", "-estimates-file", "orcamentos.json", "-log-level", "debug"},
+			env:  map[string]string{"PAINT_RATE_LIMIT": "10"},
+			want: func(c *Config) {
+				c.RateLimit = 20
+				c.WriteTimeout = 0
+				c.EstimatesFile = "orcamentos.json"
+				c.LogLevel = slog.LevelDebug
+			},
+		},
+		{
+			name: "Should_ReadAPIKeySettings_When_FileHasBoolean",
+			args: []string{"-config", writeFile(t, `{"require_api_key": true, "api_keys_file": "chaves.json"}`)},
+			env:  map[string]string{"PAINT_ADMIN_TOKEN": "segredo"},
+			want: func(c *Config) {
+				c.RequireAPIKey = true
+				c.APIKeysFile = "chaves.json"
+				c.AdminToken = "segredo"
+			},
+		},
+		{
+			name:    "Should_ReturnError_When_BooleanIsMalformed",
+			env:     map[string]string{"PAINT_REQUIRE_API_KEY": "sim"},
+			wantErr: true,
+		},
+		{
+			name:    "Should_ReturnError_When_EnvIsMalformed",
+			env:     map[string]string{"PAINT_IDLE_TIMEOUT": "10"},
+			wantErr: true,
+		},
+		{
+			name:    "Should_ReturnError_When_LogLevelIsUnknown",
+			args:    []string{"-log-level", "verbose"},
+			wantErr: true,
+		},
+		{
+			name:    "Should_ReturnError_When_FileHasUnknownKey",
+			args:    []string{"-config", writeFile(t, `{"port": 8080}`)},
+			wantErr: true,
+		},
+		{
+			name:    "Should_ReturnError_When_FlagIsUnknown",
+			args:    []string{"-port", "8080"},
+			wantErr: true,
+		},
+		{
+			name:    "Should_ReturnError_When_ValidationFails",
+			args:    []string{"-address", "8080", "-rate-limit", "0"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.args, env(tt.env), io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			want := Default()
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Load() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(c *Config)
+		wantErr bool
+	}{
+		{name: "Should_ReturnNil_When_Defaults", change: func(c *Config) {}, wantErr: false},
+		{name: "Should_ReturnNil_When_HostAndPort", change: func(c *Config) { c.Address = "127.0.0.1:8080" }, wantErr: false},
+		{name: "Should_ReturnError_When_AddressHasNoPort", change: func(c *Config) { c.Address = "localhost" }, wantErr: true},
+		{name: "Should_ReturnError_When_AppNameIsEmpty", change: func(c *Config) { c.AppName = " " }, wantErr: true},
+		{name: "Should_ReturnError_When_WindowIsZero", change: func(c *Config) { c.RateLimitWindow = 0 }, wantErr: true},
+		{name: "Should_ReturnError_When_OriginHasNoScheme", change: func(c *Config) { c.CORSOrigins = []string{"loja.com.br"} }, wantErr: true},
+		{name: "Should_ReturnError_When_NoOrigins", change: func(c *Config) { c.CORSOrigins = nil }, wantErr: true},
+		{name: "Should_ReturnError_When_BodyLimitIsZero", change: func(c *Config) { c.BodyLimit = 0 }, wantErr: true},
+		{name: "Should_ReturnError_When_TimeoutIsNegative", change: func(c *Config) { c.IdleTimeout = -time.Second }, wantErr: true},
+		{name: "Should_ReturnError_When_ShutdownTimeoutIsZero", change: func(c *Config) { c.ShutdownTimeout = 0 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			tt.change(&c)
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
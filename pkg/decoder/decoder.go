@@ -0,0 +1,256 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	invalidJSONError   = "o corpo da requisição não é um JSON valido"
+	emptyBodyError     = "o corpo da requisição está vazio"
+	trailingDataError  = "o corpo da requisição tem dados depois do JSON"
+	unknownFieldError  = "campo desconhecido"
+	requiredFieldError = "campo obrigatório"
+	expectedTypeError  = "tipo invalido, esperado "
+)
+
+// Codes of the decoding errors.
+const (
+	CodeInvalidJSON  = "invalid_json"
+	CodeUnknownField = "unknown_field"
+	CodeRequired     = "required"
+	CodeInvalidType  = "invalid_type"
+)
+
+// FieldError is one problem of the body, located by a JSON pointer such as
+// /walls/2/width. The pointer is empty when the body is not JSON at all.
+type FieldError struct {
+	Pointer string
+	Code    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Pointer == "" {
+		return e.Message
+	}
+	return e.Pointer + ": " + e.Message
+}
+
+// Errors lists every problem found in the body.
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// rawMessage is left for the caller to decode, so any JSON is accepted.
+var rawMessage = reflect.TypeOf(json.RawMessage{})
+
+// Strict decodes the JSON in data into target, a pointer to a struct. Unlike
+// json.Unmarshal it rejects fields the struct does not have, values of the
+// wrong type and missing fields tagged required:"true", reporting all of them
+// at once as Errors.
+func Strict(data []byte, target interface{}) error {
+	return decode(data, target, true)
+}
+
+// Patch is Strict for partial updates: the fields in data replace those of
+// target and the others are kept, so required fields may be missing.
+func Patch(data []byte, target interface{}) error {
+	return decode(data, target, false)
+}
+
+func decode(data []byte, target interface{}, required bool) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return Errors{{Code: CodeInvalidJSON, Message: emptyBodyError}}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	err := decoder.Decode(&document)
+	if err != nil {
+		return Errors{{Code: CodeInvalidJSON, Message: invalidJSONError}}
+	}
+	if _, err = decoder.Token(); !errors.Is(err, io.EOF) {
+		return Errors{{Code: CodeInvalidJSON, Message: trailingDataError}}
+	}
+
+	var problems Errors
+	check(reflect.TypeOf(target).Elem(), document, "", required, &problems)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	return json.Unmarshal(data, target)
+}
+
+// check compares a value decoded from JSON with the type it is going to be
+// stored in, appending the problems found under pointer. null is accepted
+// anywhere, as json.Unmarshal leaves the field untouched.
+func check(t reflect.Type, value interface{}, pointer string, required bool, problems *Errors) {
+	if value == nil || t == rawMessage {
+		return
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	invalid := func(expected string) {
+		*problems = append(*problems, &FieldError{Pointer: pointer, Code: CodeInvalidType, Message: expectedTypeError + expected})
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			invalid("objeto")
+			return
+		}
+		checkObject(t, object, pointer, required, problems)
+
+	case reflect.Slice, reflect.Array:
+		array, ok := value.([]interface{})
+		if !ok {
+			invalid("lista")
+			return
+		}
+		for i, item := range array {
+			check(t.Elem(), item, pointer+"/"+strconv.Itoa(i), required, problems)
+		}
+
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			invalid("objeto")
+			return
+		}
+		for _, key := range sortedKeys(object) {
+			check(t.Elem(), object[key], pointer+"/"+escape(key), required, problems)
+		}
+
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			invalid("texto")
+		}
+
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			invalid("true ou false")
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := value.(json.Number)
+		if !ok {
+			invalid("número inteiro")
+			return
+		}
+		if _, err := strconv.ParseInt(number.String(), 10, t.Bits()); err != nil {
+			invalid("número inteiro")
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := value.(json.Number)
+		if !ok {
+			invalid("número inteiro positivo")
+			return
+		}
+		if _, err := strconv.ParseUint(number.String(), 10, t.Bits()); err != nil {
+			invalid("número inteiro positivo")
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			invalid("número")
+		}
+	}
+}
+
+func checkObject(t reflect.Type, object map[string]interface{}, pointer string, required bool, problems *Errors) {
+	fields, names := jsonFields(t)
+
+	for _, key := range sortedKeys(object) {
+		field, ok := fields[key]
+		if !ok {
+			*problems = append(*problems, &FieldError{Pointer: pointer + "/" + escape(key), Code: CodeUnknownField, Message: unknownFieldError})
+			continue
+		}
+		check(field.Type, object[key], pointer+"/"+escape(key), required, problems)
+	}
+	if !required {
+		return
+	}
+
+	for _, name := range names {
+		if fields[name].Tag.Get("required") == "true" && object[name] == nil {
+			*problems = append(*problems, &FieldError{Pointer: pointer + "/" + escape(name), Code: CodeRequired, Message: requiredFieldError})
+		}
+	}
+}
+
+// jsonFields maps the JSON names of the fields of t, including those of
+// embedded structs, to the fields, and returns the names in field order.
+func jsonFields(t reflect.Type) (map[string]reflect.StructField, []string) {
+	fields := map[string]reflect.StructField{}
+	var names []string
+
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if field.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+				collect(embedded)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+
+			if name == "" {
+				name = field.Name
+			}
+			if _, taken := fields[name]; !taken {
+				fields[name] = field
+				names = append(names, name)
+			}
+		}
+	}
+	collect(t)
+
+	return fields, names
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escape encodes a key as a JSON pointer token (RFC 6901).
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,189 @@
+package decoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+type band struct {
+	From  float64 `json:"from"`
+	Color string  `json:"color"`
+}
+
+type wall struct {
+	Width  float64 `json:"width" required:"true"`
+	Height float64 `json:"height" required:"true"`
+	Doors  int     `json:"door_quantity"`
+	Paint  bool    `json:"paint_doors"`
+	Bands  []band  `json:"bands,omitempty"`
+}
+
+type room struct {
+	Walls []wall `json:"walls"`
+}
+
+type batchItem struct {
+	ID string `json:"id"`
+	room
+}
+
+type batch struct {
+	Rooms []batchItem `json:"rooms"`
+}
+
+func TestStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want Errors
+	}{
+		{
+			name: "Should_Decode_When_BodyIsValid",
+			body: `{"walls": [{"width": 5, "height": 2.5, "door_quantity": 1, "paint_doors": true, "bands": [{"from": 0, "color": "azul"}]}]}`,
+		},
+		{
+			name: "Should_Decode_When_OptionalFieldIsNull",
+			body: `{"walls": [{"width": 5, "height": 2.5, "bands": null}]}`,
+		},
+		{
+			name: "Should_ReturnInvalidJSON_When_BodyIsEmpty",
+			body: "  ",
+			want: Errors{{Code: CodeInvalidJSON, Message: emptyBodyError}},
+		},
+		{
+			name: "Should_ReturnInvalidJSON_When_BodyIsMalformed",
+			body: `{"walls": [`,
+			want: Errors{{Code: CodeInvalidJSON, Message: invalidJSONError}},
+		},
+		{
+			name: "Should_ReturnInvalidJSON_When_ThereIsDataAfterTheObject",
+			body: `{"walls": []} {}`,
+			want: Errors{{Code: CodeInvalidJSON, Message: trailingDataError}},
+		},
+		{
+			name: "Should_ReturnUnknownField_When_FieldIsNotExpected",
+			body: `{"walls": [{"width": 5, "height": 2.5, "doors": 1}]}`,
+			want: Errors{{Pointer: "/walls/0/doors", Code: CodeUnknownField, Message: unknownFieldError}},
+		},
+		{
+			name: "Should_ReturnRequired_When_HeightIsMissing",
+			body: `{"walls": [{"width": 5, "height": 2.5}, {"width": 5}]}`,
+			want: Errors{{Pointer: "/walls/1/height", Code: CodeRequired, Message: requiredFieldError}},
+		},
+		{
+			name: "Should_ReturnRequired_When_WidthIsNull",
+			body: `{"walls": [{"width": null, "height": 2.5}]}`,
+			want: Errors{{Pointer: "/walls/0/width", Code: CodeRequired, Message: requiredFieldError}},
+		},
+		{
+			name: "Should_ReturnInvalidType_When_ValuesHaveTheWrongType",
+			body: `{"walls": [{"width": "5", "height": 2.5, "door_quantity": 1.5, "paint_doors": "sim", "bands": [{"from": 0, "color": 3}]}]}`,
+			want: Errors{
+				{Pointer: "/walls/0/bands/0/color", Code: CodeInvalidType, Message: expectedTypeError + "texto"},
+				{Pointer: "/walls/0/door_quantity", Code: CodeInvalidType, Message: expectedTypeError + "número inteiro"},
+				{Pointer: "/walls/0/paint_doors", Code: CodeInvalidType, Message: expectedTypeError + "true ou false"},
+				{Pointer: "/walls/0/width", Code: CodeInvalidType, Message: expectedTypeError + "número"},
+			},
+		},
+		{
+			name: "Should_ReturnInvalidType_When_WallsIsNotAList",
+			body: `{"walls": {"width": 5}}`,
+			want: Errors{{Pointer: "/walls", Code: CodeInvalidType, Message: expectedTypeError + "lista"}},
+		},
+		{
+			name: "Should_ReturnInvalidType_When_BodyIsNotAnObject",
+			body: `[]`,
+			want: Errors{{Pointer: "", Code: CodeInvalidType, Message: expectedTypeError + "objeto"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got room
+			err := Strict([]byte(tt.body), &got)
+
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Strict() error = %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(err, tt.want) {
+				t.Errorf("Strict() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrict_EmbeddedFields(t *testing.T) {
+	var got batch
+	err := Strict([]byte(`{"rooms": [{"id": "sala", "walls": [{"width": 5, "height": 2.5}]}, {"id": "cozinha", "walls": [{"height": 2.5}], "area": 3}]}`), &got)
+
+	want := Errors{
+		{Pointer: "/rooms/1/area", Code: CodeUnknownField, Message: unknownFieldError},
+		{Pointer: "/rooms/1/walls/0/width", Code: CodeRequired, Message: requiredFieldError},
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Strict() error = %v, want %v", err, want)
+	}
+}
+
+func TestStrict_DecodesValidBody(t *testing.T) {
+	var got room
+	err := Strict([]byte(`{"walls": [{"width": 5, "height": 2.5, "door_quantity": 1}]}`), &got)
+	if err != nil {
+		t.Fatalf("Strict() error = %v", err)
+	}
+
+	want := room{Walls: []wall{{Width: 5, Height: 2.5, Doors: 1}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Strict() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    wall
+		wantErr Errors
+	}{
+		{
+			name: "Should_KeepOtherFields_When_OneFieldIsPatched",
+			body: `{"door_quantity": 2}`,
+			want: wall{Width: 5, Height: 2.5, Doors: 2},
+		},
+		{
+			name: "Should_ClearField_When_ZeroIsSent",
+			body: `{"width": 0, "paint_doors": true}`,
+			want: wall{Height: 2.5, Doors: 1, Paint: true},
+		},
+		{
+			name:    "Should_ReturnUnknownField_When_FieldIsNotExpected",
+			body:    `{"doors": 2}`,
+			want:    wall{Width: 5, Height: 2.5, Doors: 1},
+			wantErr: Errors{{Pointer: "/doors", Code: CodeUnknownField, Message: unknownFieldError}},
+		},
+		{
+			name:    "Should_ReturnInvalidType_When_ValueHasTheWrongType",
+			body:    `{"width": "5"}`,
+			want:    wall{Width: 5, Height: 2.5, Doors: 1},
+			wantErr: Errors{{Pointer: "/width", Code: CodeInvalidType, Message: expectedTypeError + "número"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wall{Width: 5, Height: 2.5, Doors: 1}
+			err := Patch([]byte(tt.body), &got)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Patch() error = %v", err)
+			}
+			if tt.wantErr != nil && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Patch() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Patch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package entities
+
+import "errors"
+
+// Codes of the validation errors. They are stable identifiers for clients and
+// metrics, unlike the messages, which are meant to be read by people.
+const (
+	CodeMeasurePrecision   = "measure_precision"
+	CodeMeasureRange       = "measure_range"
+	CodeWallWidthNegative  = "wall_width_negative"
+	CodeWallHeightNegative = "wall_height_negative"
+	CodeWallAreaLimit      = "wall_area_limit"
+	CodeWallMinimumPaint   = "wall_minimum_paint"
+	CodeWallLimit          = "wall_limit"
+	CodeOpeningsAreaLimit  = "openings_area_limit"
+	CodeDoorHeight         = "door_height"
+	CodeTrimNegative       = "trim_negative"
+	CodeTrimLimit          = "trim_limit"
+	CodeBandLimit          = "band_limit"
+	CodeBandOrder          = "band_order"
+	CodeBandColor          = "band_color"
+	CodeBandOverlap        = "band_overlap"
+)
+
+// ValidationError is returned when the input breaks a rule of the domain.
+type ValidationError struct {
+	Code    string
+	Message string
+}
+
+func NewValidationError(code, message string) error {
+	return &ValidationError{Code: code, Message: message}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationCode returns the code of the validation error in the chain of
+// err, or an empty string when err is not a validation error.
+func ValidationCode(err error) string {
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		return validation.Code
+	}
+	return ""
+}
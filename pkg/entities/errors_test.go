@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestValidationCode(t *testing.T) {
+	_, wallErr := NewWall(-100, 100)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "Should_ReturnCode_When_ValidationError", err: wallErr, want: CodeWallWidthNegative},
+		{name: "Should_ReturnCode_When_WrappedValidationError", err: fmt.Errorf("sala: %w", wallErr), want: CodeWallWidthNegative},
+		{name: "Should_ReturnEmpty_When_OtherError", err: errors.New("falha"), want: ""},
+		{name: "Should_ReturnEmpty_When_Nil", err: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidationCode(tt.err); got != tt.want {
+				t.Errorf("ValidationCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
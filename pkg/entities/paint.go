@@ -1,26 +1,27 @@
 package entities
 
-import (
-	"errors"
-	"math"
-)
-
 const (
-	maximumRoomWalls      = 4
-	minimumRoomWallsArea  = 1.0
-	maximumRoomWallsArea  = 50.0
-	metersPaintedPerLiter = 5
-	maxDoorHeight         = 0.3
-	limitWindowAndDoor    = 0.5
-	minimumWallAreaPaint  = 0.5
+	maximumRoomWalls                                = 4
+	minimumRoomWallsArea          SquareCentimeters = 10000
+	maximumRoomWallsArea          SquareCentimeters = 500000
+	squareCentimetersPaintedPerMl SquareCentimeters = 50
+	maxDoorHeight                 Centimeters       = 30
+	limitWindowAndDoorFactor                        = 2
+	minimumWallAreaPaint          Milliliters       = 500
+
+	squareCentimetersEnamelPaintedPerMl SquareCentimeters = 100
+	frameWidth                          Centimeters       = 5
+	trimHeight                          Centimeters       = 10
 )
 
 const (
-	WidthWindow  = 2.0
-	HeightWindow = 1.2
+	WidthWindow  Centimeters = 200
+	HeightWindow Centimeters = 120
 
-	WidthDoor  = 0.8
-	HeightDoor = 1.9
+	WidthDoor  Centimeters = 80
+	HeightDoor Centimeters = 190
+
+	SillHeightWindow Centimeters = 100
 )
 
 const (
@@ -31,58 +32,95 @@ const (
 	wallHeightNegativeError        = "tamanho da parede invalido: A altura da parede não pode ser menor que 0"
 	maxDoorHeightError             = "a altura mínima da parede deve ser 30 centímetros a mais do que a altura da porta"
 	minWallAreaPaintError          = "a área minima da parede deve corresponder ao menor tamanho da tinta 0.5L"
+	bandLimitError                 = "faixa invalida: a faixa precisa estar entre o chão e a altura da parede"
+	bandOrderError                 = "faixa invalida: a altura inicial da faixa deve ser menor que a altura final"
+	bandOverlapError               = "faixa invalida: as faixas de uma parede não podem se sobrepor"
+	bandColorError                 = "faixa invalida: é necessario informar a cor da faixa"
+	trimNegativeError              = "o comprimento do rodapé não pode ser menor que 0"
+	trimLimitError                 = "o comprimento do rodapé não pode ser maior que a largura da parede"
 )
 
-type Can float64
+// Can is the volume of a paint can, in milliliters.
+type Can Milliliters
+
+const (
+	HugeCan   Can = 18000
+	BigCan    Can = 3600
+	MediumCan Can = 2500
+	SmallCan  Can = 500
+)
 
 const (
-	HugeCan   Can = 18.0
-	BigCan    Can = 3.6
-	MediumCan Can = 2.5
-	SmallCan  Can = 0.5
+	EnamelBigCan    Can = 3600
+	EnamelMediumCan Can = 900
+	EnamelSmallCan  Can = 225
+)
+
+var (
+	wallPaintCans   = []Can{HugeCan, BigCan, MediumCan, SmallCan}
+	enamelPaintCans = []Can{EnamelBigCan, EnamelMediumCan, EnamelSmallCan}
 )
 
 type Dimensions interface {
-	calcArea() float64
+	calcArea() SquareCentimeters
 }
 
 type Wall struct {
-	Width   float64
-	Height  float64
-	Doors   []Door
-	Windows []Window
+	Width      Centimeters
+	Height     Centimeters
+	Doors      []Door
+	Windows    []Window
+	Bands      []Band
+	TrimLength Centimeters
 }
 
+// Band is a horizontal strip of a wall, measured from the floor, painted in
+// its own colour or left unpainted (e.g. above a dado rail).
+type Band struct {
+	From      Centimeters
+	To        Centimeters
+	Color     string
+	Unpainted bool
+}
+
+// Door is discounted from the wall area. When Painted, both faces of the
+// leaf are painted in enamel; when FramePainted, so are both faces of its frame.
 type Door struct {
-	Width  float64
-	Height float64
+	Width        Centimeters
+	Height       Centimeters
+	Painted      bool
+	FramePainted bool
 }
 
+// Window is discounted from the wall area. When FramePainted, the room side
+// of its frame is painted in enamel.
 type Window struct {
-	Width  float64
-	Height float64
+	Width        Centimeters
+	Height       Centimeters
+	Sill         Centimeters
+	FramePainted bool
 }
 
 type PaintBudgetCalculator struct {
 }
 
-func NewWall(width, height float64) (Wall, error) {
-	totalAreaInSquareMeters := width * height
+func NewWall(width, height Centimeters) (Wall, error) {
+	totalAreaInSquareCentimeters := calcRectangleArea(width, height)
 	switch {
 	case width < 0:
-		return Wall{}, errors.New(wallWidhtNegativeError)
+		return Wall{}, NewValidationError(CodeWallWidthNegative, wallWidhtNegativeError)
 
 	case height < 0:
-		return Wall{}, errors.New(wallHeightNegativeError)
+		return Wall{}, NewValidationError(CodeWallHeightNegative, wallHeightNegativeError)
 
-	case totalAreaInSquareMeters < minimumRoomWallsArea:
-		return Wall{}, errors.New(wallAreaLimitError)
+	case totalAreaInSquareCentimeters < minimumRoomWallsArea:
+		return Wall{}, NewValidationError(CodeWallAreaLimit, wallAreaLimitError)
 
-	case totalAreaInSquareMeters > maximumRoomWallsArea:
-		return Wall{}, errors.New(wallAreaLimitError)
+	case totalAreaInSquareCentimeters > maximumRoomWallsArea:
+		return Wall{}, NewValidationError(CodeWallAreaLimit, wallAreaLimitError)
 
-	case calcLitersPerMeterPainted(totalAreaInSquareMeters) < minimumWallAreaPaint:
-		return Wall{}, errors.New(minWallAreaPaintError)
+	case calcMillilitersPainted(totalAreaInSquareCentimeters) < minimumWallAreaPaint:
+		return Wall{}, NewValidationError(CodeWallMinimumPaint, minWallAreaPaintError)
 
 	}
 
@@ -91,9 +129,9 @@ func NewWall(width, height float64) (Wall, error) {
 
 }
 
-func (w *Wall) calcArea() float64 {
-	doorsArea := 0.0
-	windowsArea := 0.0
+func (w *Wall) calcArea() SquareCentimeters {
+	doorsArea := SquareCentimeters(0)
+	windowsArea := SquareCentimeters(0)
 
 	for _, door := range w.Doors {
 		doorsArea += door.calcArea()
@@ -102,11 +140,23 @@ func (w *Wall) calcArea() float64 {
 		windowsArea += window.calcArea()
 	}
 
-	return (w.Width * w.Height) - (doorsArea + windowsArea)
+	return calcRectangleArea(w.Width, w.Height) - (doorsArea + windowsArea)
 }
 
-func (d *Door) calcArea() float64 {
-	return d.Width * d.Height
+func (d *Door) calcArea() SquareCentimeters {
+	return calcRectangleArea(d.Width, d.Height)
+}
+
+func (d *Door) calcEnamelArea() SquareCentimeters {
+	area := SquareCentimeters(0)
+	if d.Painted {
+		area += 2 * d.calcArea()
+	}
+	if d.FramePainted {
+		area += 2 * calcRectangleArea(2*d.Height+d.Width, frameWidth)
+	}
+
+	return area
 }
 
 func (w *Wall) ValidateDoors() error {
@@ -121,7 +171,7 @@ func (w *Wall) ValidateDoors() error {
 	}
 
 	if w.isWindowsAndDoorsAreaHigherThanWallArea() {
-		return errors.New(doorsAndWindowsAreaInWallError)
+		return NewValidationError(CodeOpeningsAreaLimit, doorsAndWindowsAreaInWallError)
 	}
 	return nil
 }
@@ -129,7 +179,7 @@ func (w *Wall) ValidateDoors() error {
 func (w *Wall) IsDoorHeightWithMax(door Door) error {
 
 	if w.Height-door.Height < maxDoorHeight {
-		return errors.New(maxDoorHeightError)
+		return NewValidationError(CodeDoorHeight, maxDoorHeightError)
 
 	}
 	return nil
@@ -138,13 +188,105 @@ func (w *Wall) IsDoorHeightWithMax(door Door) error {
 func (w *Wall) ValidateWindow() error {
 
 	if w.isWindowsAndDoorsAreaHigherThanWallArea() {
-		return errors.New(doorsAndWindowsAreaInWallError)
+		return NewValidationError(CodeOpeningsAreaLimit, doorsAndWindowsAreaInWallError)
+	}
+	return nil
+}
+
+func (w *Window) calcArea() SquareCentimeters {
+	return calcRectangleArea(w.Width, w.Height)
+}
+
+func (w *Window) calcEnamelArea() SquareCentimeters {
+	if !w.FramePainted {
+		return 0
+	}
+
+	return calcRectangleArea(2*(w.Width+w.Height), frameWidth)
+}
+
+func (w *Wall) SetTrimLength(length Centimeters) error {
+	switch {
+	case length < 0:
+		return NewValidationError(CodeTrimNegative, trimNegativeError)
+
+	case length > w.Width:
+		return NewValidationError(CodeTrimLimit, trimLimitError)
+	}
+
+	w.TrimLength = length
+	return nil
+}
+
+// calcEnamelArea returns the area of doors, frames and baseboard trim of the
+// wall that is painted in enamel instead of wall paint.
+func (w *Wall) calcEnamelArea() SquareCentimeters {
+	area := calcRectangleArea(w.TrimLength, trimHeight)
+
+	for _, door := range w.Doors {
+		area += door.calcEnamelArea()
+	}
+	for _, window := range w.Windows {
+		area += window.calcEnamelArea()
 	}
+
+	return area
+}
+
+func (w *Wall) AddBand(band Band) error {
+	switch {
+	case band.From < 0 || band.To > w.Height:
+		return NewValidationError(CodeBandLimit, bandLimitError)
+
+	case band.From >= band.To:
+		return NewValidationError(CodeBandOrder, bandOrderError)
+
+	case !band.Unpainted && band.Color == "":
+		return NewValidationError(CodeBandColor, bandColorError)
+	}
+
+	for _, bandActual := range w.Bands {
+		if band.From < bandActual.To && bandActual.From < band.To {
+			return NewValidationError(CodeBandOverlap, bandOverlapError)
+		}
+	}
+
+	w.Bands = append(w.Bands, band)
 	return nil
 }
 
-func (w *Window) calcArea() float64 {
-	return w.Width * w.Height
+// calcBandArea returns the paintable area of the band, discounting the part
+// of every door and window that crosses it. Doors start at the floor and
+// windows at their sill. Openings wider than the wall leave nothing to
+// paint, never a negative area that would be taken from the other bands.
+func (w *Wall) calcBandArea(band Band) SquareCentimeters {
+	area := calcRectangleArea(w.Width, band.To-band.From)
+
+	for _, door := range w.Doors {
+		area -= calcRectangleArea(door.Width, band.overlap(0, door.Height))
+	}
+	for _, window := range w.Windows {
+		area -= calcRectangleArea(window.Width, band.overlap(window.Sill, window.Sill+window.Height))
+	}
+
+	if area < 0 {
+		return 0
+	}
+	return area
+}
+
+// calcBaseArea returns the area of the wall that is not covered by any band.
+func (w *Wall) calcBaseArea() SquareCentimeters {
+	area := w.calcArea()
+	for _, band := range w.Bands {
+		area -= w.calcBandArea(band)
+	}
+
+	return area
+}
+
+func (b *Band) overlap(bottom, top Centimeters) Centimeters {
+	return maxCentimeters(0, minCentimeters(b.To, top)-maxCentimeters(b.From, bottom))
 }
 
 type Room struct {
@@ -155,7 +297,7 @@ func (r *Room) AddWall(wall Wall) error {
 
 	r.Walls = append(r.Walls, wall)
 	if r.HasMoreThanForWalls() {
-		return errors.New(wallLimitError)
+		return NewValidationError(CodeWallLimit, wallLimitError)
 	}
 	return nil
 }
@@ -165,9 +307,9 @@ func (r *Room) HasMoreThanForWalls() bool {
 	return len(r.Walls) > maximumRoomWalls
 
 }
-func (r *Room) calcArea() float64 {
+func (r *Room) calcArea() SquareCentimeters {
 
-	area := 0.0
+	area := SquareCentimeters(0)
 	for _, wall := range r.Walls {
 		area += wall.calcArea()
 	}
@@ -176,39 +318,124 @@ func (r *Room) calcArea() float64 {
 
 }
 
-func (p *PaintBudgetCalculator) CalculatePaintBudget(room Room) []Can {
-	paintCans := []Can{}
-	Cans := [4]Can{
-		HugeCan,
-		BigCan,
-		MediumCan,
-		SmallCan,
+func (r *Room) calcBaseArea() SquareCentimeters {
+
+	area := SquareCentimeters(0)
+	for _, wall := range r.Walls {
+		area += wall.calcBaseArea()
 	}
-	numberOfCans := 0.0
 
-	roomArea := calcLitersPerMeterPainted(room.calcArea())
+	return area
 
-	for can := 0; can < len(Cans); can++ {
-		if roomArea >= float64(Cans[can]) {
+}
 
-			if can != len(Cans)-1 {
-				numberOfCans = math.Floor(roomArea / float64(Cans[can]))
-				for i := 0; i < int(numberOfCans); i++ {
-					paintCans = append(paintCans, Cans[can])
+func (r *Room) calcEnamelArea() SquareCentimeters {
 
-					roomArea = math.Mod(roomArea, float64(Cans[can]))
-				}
+	area := SquareCentimeters(0)
+	for _, wall := range r.Walls {
+		area += wall.calcEnamelArea()
+	}
 
-			} else {
-				numberOfCans = math.Ceil(roomArea / float64(Cans[can]))
-				for i := 0; i < int(numberOfCans); i++ {
-					paintCans = append(paintCans, Cans[can])
+	return area
 
-				}
+}
+
+func (r *Room) calcAreaByColor() map[string]SquareCentimeters {
+
+	areas := map[string]SquareCentimeters{}
+	for _, wall := range r.Walls {
+		for _, band := range wall.Bands {
+			if band.Unpainted {
+				continue
 			}
+			areas[band.Color] += wall.calcBandArea(band)
+		}
+	}
+
+	return areas
+
+}
+
+// CalculatePaintedArea returns the wall area that takes wall paint, the
+// unpainted bands left out.
+func (p *PaintBudgetCalculator) CalculatePaintedArea(room Room) SquareCentimeters {
+	area := room.calcBaseArea()
+	for _, colorArea := range room.calcAreaByColor() {
+		area += colorArea
+	}
+
+	return area
+}
+
+// CalculateWallPaintedArea returns the area of a single wall that takes wall
+// paint, the unpainted bands left out.
+func (p *PaintBudgetCalculator) CalculateWallPaintedArea(wall Wall) SquareCentimeters {
+	area := wall.calcBaseArea()
+	for _, band := range wall.Bands {
+		if !band.Unpainted {
+			area += wall.calcBandArea(band)
 		}
 	}
 
+	return area
+}
+
+// CalculateWallPaintVolume returns the wall paint needed for a single wall.
+func (p *PaintBudgetCalculator) CalculateWallPaintVolume(wall Wall) Milliliters {
+	return calcMillilitersPainted(p.CalculateWallPaintedArea(wall))
+}
+
+// CalculatePaintVolume returns the wall paint needed for the room, each band
+// colour rounded up on its own as it is bought separately.
+func (p *PaintBudgetCalculator) CalculatePaintVolume(room Room) Milliliters {
+	volume := calcMillilitersPainted(room.calcBaseArea())
+	for _, colorArea := range room.calcAreaByColor() {
+		volume += calcMillilitersPainted(colorArea)
+	}
+
+	return volume
+}
+
+func (p *PaintBudgetCalculator) CalculatePaintBudget(room Room) []Can {
+	return calcCans(calcMillilitersPainted(room.calcBaseArea()), wallPaintCans)
+}
+
+// CalculatePaintBudgetByColor returns the cans needed for each band colour of
+// the room. The area outside the bands is covered by CalculatePaintBudget.
+func (p *PaintBudgetCalculator) CalculatePaintBudgetByColor(room Room) map[string][]Can {
+	budget := map[string][]Can{}
+	for color, area := range room.calcAreaByColor() {
+		budget[color] = calcCans(calcMillilitersPainted(area), wallPaintCans)
+	}
+
+	return budget
+}
+
+// CalculateEnamelBudget returns the enamel cans needed for the painted doors,
+// frames and trims of the room.
+func (p *PaintBudgetCalculator) CalculateEnamelBudget(room Room) []Can {
+	return calcCans(calcMillilitersEnamelPainted(room.calcEnamelArea()), enamelPaintCans)
+}
+
+// calcCans fills the volume with as many of the biggest cans as fit, moving
+// on to smaller ones, and rounds whatever is left up to the smallest can.
+func calcCans(volume Milliliters, cans []Can) []Can {
+	paintCans := []Can{}
+
+	for can := 0; can < len(cans); can++ {
+		size := Milliliters(cans[can])
+
+		numberOfCans := volume / size
+		if can == len(cans)-1 {
+			numberOfCans = (volume + size - 1) / size
+		}
+
+		for i := Milliliters(0); i < numberOfCans; i++ {
+			paintCans = append(paintCans, cans[can])
+		}
+		volume -= numberOfCans * size
+	}
+
 	return paintCans
 }
 
@@ -216,8 +443,8 @@ func (w *Wall) isWindowsAndDoorsAreaHigherThanWallArea() bool {
 
 	wallArea := w.calcArea()
 
-	doorsArea := 0.0
-	windowsArea := 0.0
+	doorsArea := SquareCentimeters(0)
+	windowsArea := SquareCentimeters(0)
 
 	for _, door := range w.Doors {
 		doorsArea += door.calcArea()
@@ -230,11 +457,17 @@ func (w *Wall) isWindowsAndDoorsAreaHigherThanWallArea() bool {
 	totalWallArea := wallArea + doorsArea + windowsArea
 	windowsAndDoorsArea := windowsArea + doorsArea
 
-	return windowsAndDoorsArea > limitWindowAndDoor*totalWallArea
+	return limitWindowAndDoorFactor*windowsAndDoorsArea > totalWallArea
 
 }
 
-func calcLitersPerMeterPainted(roomArea float64) float64 {
-	return roomArea / metersPaintedPerLiter
+// calcMillilitersPainted returns the paint needed to cover the area, rounded
+// up to the next milliliter.
+func calcMillilitersPainted(roomArea SquareCentimeters) Milliliters {
+	return Milliliters((roomArea + squareCentimetersPaintedPerMl - 1) / squareCentimetersPaintedPerMl)
+
+}
 
+func calcMillilitersEnamelPainted(area SquareCentimeters) Milliliters {
+	return Milliliters((area + squareCentimetersEnamelPaintedPerMl - 1) / squareCentimetersEnamelPaintedPerMl)
 }
@@ -7,8 +7,8 @@ import (
 
 func TestNewWall(t *testing.T) {
 	type args struct {
-		width  float64
-		height float64
+		width  Centimeters
+		height Centimeters
 	}
 	tests := []struct {
 		name    string
@@ -18,50 +18,50 @@ func TestNewWall(t *testing.T) {
 	}{
 
 		{name: "Should_ReturnPassedNewWall_When_ValidParameters", args: args{
-			width:  5,
-			height: 5,
+			width:  500,
+			height: 500,
 		}, want: Wall{
-			Width:   5,
-			Height:  5,
+			Width:   500,
+			Height:  500,
 			Doors:   nil,
 			Windows: nil,
 		},
 			wantErr: false},
 		{name: "Should_WallWidhtNegativeError_When_NegativeWidhtParameter", args: args{
-			width:  -1,
-			height: 1,
+			width:  -100,
+			height: 100,
 		}, want: Wall{
-			Width:   0.0,
-			Height:  0.0,
+			Width:   0,
+			Height:  0,
 			Doors:   nil,
 			Windows: nil,
 		},
 			wantErr: true},
 		{name: "Should_WallHeightNegativeError_When_NegativeHeightParameter", args: args{
-			width:  1,
-			height: -1,
+			width:  100,
+			height: -100,
 		}, want: Wall{
-			Width:   0.0,
-			Height:  0.0,
+			Width:   0,
+			Height:  0,
 			Doors:   nil,
 			Windows: nil,
 		},
 			wantErr: true},
 
 		{name: "Should_MinWallAreaLimitError_When_SmallAreaParameters", args: args{
-			width:  0.5,
-			height: 0.5,
+			width:  50,
+			height: 50,
 		}, want: Wall{
-			Width:   0.0,
-			Height:  0.0,
+			Width:   0,
+			Height:  0,
 			Doors:   nil,
 			Windows: nil,
 		},
 			wantErr: true},
 
 		{name: "Should_MaxWallAreaLimitError_When_LargeAreaParameters", args: args{
-			width:  10,
-			height: 10,
+			width:  1000,
+			height: 1000,
 		}, want: Wall{
 			Width:   0,
 			Height:  0,
@@ -82,8 +82,8 @@ func TestNewWall(t *testing.T) {
 			wantErr: true},
 
 		{name: "Should_MinWallAreaPaintError_When_AreaParametersBelow", args: args{
-			width:  1,
-			height: 1,
+			width:  100,
+			height: 100,
 		}, want: Wall{
 			Width:   0,
 			Height:  0,
@@ -108,8 +108,8 @@ func TestNewWall(t *testing.T) {
 
 func TestWall_IsDoorHeightWithMax(t *testing.T) {
 	type fields struct {
-		Width   float64
-		Height  float64
+		Width   Centimeters
+		Height  Centimeters
 		doors   []Door
 		windows []Window
 	}
@@ -123,8 +123,8 @@ func TestWall_IsDoorHeightWithMax(t *testing.T) {
 		wantErr bool
 	}{
 		{name: "Shoul_ReturnPassedHeightWall_When_ValidParameters", fields: fields{
-			Width:   5,
-			Height:  2.20,
+			Width:   500,
+			Height:  220,
 			doors:   nil,
 			windows: nil,
 		}, args: args{door: Door{
@@ -132,8 +132,8 @@ func TestWall_IsDoorHeightWithMax(t *testing.T) {
 			Height: HeightDoor,
 		}}, wantErr: false},
 		{name: "Shoul_MaxDoorHeightError_When_HeightWallBelowTheLimit", fields: fields{
-			Width:   5,
-			Height:  2.19,
+			Width:   500,
+			Height:  219,
 			doors:   nil,
 			windows: nil,
 		}, args: args{door: Door{
@@ -159,25 +159,25 @@ func TestWall_IsDoorHeightWithMax(t *testing.T) {
 func TestWall_calcArea(t *testing.T) {
 
 	type fields struct {
-		Width   float64
-		Height  float64
+		Width   Centimeters
+		Height  Centimeters
 		doors   []Door
 		windows []Window
 	}
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   SquareCentimeters
 	}{
 		{
 			name: "Should_ReturnPassedWallArea_When_ValidParameters",
 			fields: fields{
-				Width:   5,
-				Height:  5,
+				Width:   500,
+				Height:  500,
 				doors:   nil,
 				windows: nil,
 			},
-			want: 25,
+			want: 250000,
 		},
 		{
 			name: "Should_ReturnPassedWallArea_When_ZeroParameters",
@@ -207,8 +207,8 @@ func TestWall_calcArea(t *testing.T) {
 
 func TestWall_isWindowsAndDoorsAreaHigherThanWallArea(t *testing.T) {
 	type fields struct {
-		Width   float64
-		Height  float64
+		Width   Centimeters
+		Height  Centimeters
 		doors   []Door
 		windows []Window
 	}
@@ -220,8 +220,8 @@ func TestWall_isWindowsAndDoorsAreaHigherThanWallArea(t *testing.T) {
 		{
 			name: "Should_ReturnPassedWindowsAndDoorsArea_When_ValidParameters",
 			fields: fields{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				doors: []Door{{
 					Width:  WidthDoor,
 					Height: HeightDoor,
@@ -236,8 +236,8 @@ func TestWall_isWindowsAndDoorsAreaHigherThanWallArea(t *testing.T) {
 		{
 			name: "Should_WindowsAndDoorsAreaHigherThanWallAreaTrue_When_WindowsOverLimitParameters",
 			fields: fields{
-				Width:  2,
-				Height: 2,
+				Width:  200,
+				Height: 200,
 				doors: []Door{{
 					Width:  WidthDoor,
 					Height: HeightDoor,
@@ -262,8 +262,8 @@ func TestWall_isWindowsAndDoorsAreaHigherThanWallArea(t *testing.T) {
 		{
 			name: "Should_WindowsAndDoorsAreaHigherThanWallAreaTrue_When_DoorsOverLimitParameters",
 			fields: fields{
-				Width:  2,
-				Height: 2,
+				Width:  200,
+				Height: 200,
 				doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -325,27 +325,27 @@ func TestRoom_AddWall(t *testing.T) {
 			name: "Should_ReturnPassedWall_When_WallValidParameters",
 			fields: fields{walls: []Wall{
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 			}},
 			args: args{Wall{
-				Width:   5,
-				Height:  5,
+				Width:   500,
+				Height:  500,
 				Doors:   nil,
 				Windows: nil,
 			}},
@@ -355,33 +355,33 @@ func TestRoom_AddWall(t *testing.T) {
 			name: "Should_WallLimitError_When_OverLimitWalls",
 			fields: fields{walls: []Wall{
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 			}},
 			args: args{Wall{
-				Width:   5,
-				Height:  5,
+				Width:   500,
+				Height:  500,
 				Doors:   nil,
 				Windows: nil,
 			}},
@@ -418,26 +418,26 @@ func TestRoom_HasMoreThanForWalls(t *testing.T) {
 		{name: "Should_ReturnPassedLimitWalls_When_ValidQuantityWallsAsParameter",
 			fields: fields{walls: []Wall{
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
@@ -446,32 +446,32 @@ func TestRoom_HasMoreThanForWalls(t *testing.T) {
 		{name: "Should_MaximumRoomWallsError_When_InvalidQuantityWallsAsParameter",
 			fields: fields{walls: []Wall{
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
 				{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
@@ -497,39 +497,39 @@ func TestRoom_calcArea(t *testing.T) {
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   SquareCentimeters
 	}{
 		{
 			name: "Should_ReturnPassedRoomArea_When_ValidParameters",
 			fields: fields{
 				walls: []Wall{
 					{
-						Width:   5,
-						Height:  5,
+						Width:   500,
+						Height:  500,
 						Doors:   nil,
 						Windows: nil,
 					},
 					{
-						Width:   5,
-						Height:  5,
+						Width:   500,
+						Height:  500,
 						Doors:   nil,
 						Windows: nil,
 					},
 					{
-						Width:   5,
-						Height:  5,
+						Width:   500,
+						Height:  500,
 						Doors:   nil,
 						Windows: nil,
 					},
 					{
-						Width:   5,
-						Height:  5,
+						Width:   500,
+						Height:  500,
 						Doors:   nil,
 						Windows: nil,
 					},
 				},
 			},
-			want: 100,
+			want: 1000000,
 		},
 		{
 			name: "Should_ReturnPassedRoomArea_When_ZeroParameters",
@@ -578,8 +578,8 @@ func TestRoom_calcArea(t *testing.T) {
 
 func TestWall_ValidateDoors(t *testing.T) {
 	type fields struct {
-		Width   float64
-		Height  float64
+		Width   Centimeters
+		Height  Centimeters
 		Doors   []Door
 		Windows []Window
 	}
@@ -592,8 +592,8 @@ func TestWall_ValidateDoors(t *testing.T) {
 		{
 			name: "Should_ReturnPassedDoor_When_ValidParameters",
 			fields: fields{
-				Width:  2,
-				Height: 4,
+				Width:  200,
+				Height: 400,
 				Doors: []Door{{
 					Width:  WidthDoor,
 					Height: HeightDoor,
@@ -605,8 +605,8 @@ func TestWall_ValidateDoors(t *testing.T) {
 		{
 			name: "Should_MaxDoorHeightError_When_DoorsOverLimitHeight",
 			fields: fields{
-				Width:  2,
-				Height: 1,
+				Width:  200,
+				Height: 100,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -624,8 +624,8 @@ func TestWall_ValidateDoors(t *testing.T) {
 		{
 			name: "Should_DoorsAreaInWallError_When_DoorsOverLimit",
 			fields: fields{
-				Width:  1,
-				Height: 5,
+				Width:  100,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -643,8 +643,8 @@ func TestWall_ValidateDoors(t *testing.T) {
 		{
 			name: "Should_ReturnPassedDoor_When_ZeroDoorsParameters",
 			fields: fields{
-				Width:   2,
-				Height:  2,
+				Width:   200,
+				Height:  200,
 				Doors:   nil,
 				Windows: nil,
 			},
@@ -668,16 +668,16 @@ func TestWall_ValidateDoors(t *testing.T) {
 
 func TestDoor_calcArea(t *testing.T) {
 
-	doorAreaResult := WidthDoor * HeightDoor
+	doorAreaResult := SquareCentimeters(WidthDoor * HeightDoor)
 
 	type fields struct {
-		Width  float64
-		Height float64
+		Width  Centimeters
+		Height Centimeters
 	}
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   SquareCentimeters
 	}{
 
 		{name: "Should_ReturnPassedDoorArea_When_ValidParameters", fields: fields{
@@ -705,8 +705,8 @@ func TestDoor_calcArea(t *testing.T) {
 
 func TestWall_ValidateWindow(t *testing.T) {
 	type fields struct {
-		Width   float64
-		Height  float64
+		Width   Centimeters
+		Height  Centimeters
 		Doors   []Door
 		Windows []Window
 	}
@@ -718,8 +718,8 @@ func TestWall_ValidateWindow(t *testing.T) {
 		{
 			name: "Should_ReturnPassedWindow_When_ValidParameters",
 			fields: fields{
-				Width:  2,
-				Height: 5,
+				Width:  200,
+				Height: 500,
 				Doors:  nil,
 				Windows: []Window{{
 					Width:  WidthWindow,
@@ -731,8 +731,8 @@ func TestWall_ValidateWindow(t *testing.T) {
 		{
 			name: "Should_WindowsAreaInWallError_When_WindowsOverLimitParameters",
 			fields: fields{
-				Width:  2,
-				Height: 3,
+				Width:  200,
+				Height: 300,
 				Doors:  nil,
 				Windows: []Window{
 					{
@@ -750,8 +750,8 @@ func TestWall_ValidateWindow(t *testing.T) {
 		{
 			name: "Should_ReturnPassedWindow_When_ZeroWindowsParameters",
 			fields: fields{
-				Width:   2,
-				Height:  2,
+				Width:   200,
+				Height:  200,
 				Doors:   nil,
 				Windows: []Window{},
 			},
@@ -775,16 +775,16 @@ func TestWall_ValidateWindow(t *testing.T) {
 
 func TestWindow_calcArea(t *testing.T) {
 
-	windowAreaResult := WidthWindow * HeightWindow
+	windowAreaResult := SquareCentimeters(WidthWindow * HeightWindow)
 
 	type fields struct {
-		Width  float64
-		Height float64
+		Width  Centimeters
+		Height Centimeters
 	}
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   SquareCentimeters
 	}{
 
 		{name: "Should_ReturnPassedWindowArea_When_ValidParameters", fields: fields{
@@ -810,24 +810,47 @@ func TestWindow_calcArea(t *testing.T) {
 	}
 }
 
-func Test_calcLitersPerMeterPainted(t *testing.T) {
+func Test_calcMillilitersPainted(t *testing.T) {
+
+	type args struct {
+		roomArea SquareCentimeters
+	}
+	tests := []struct {
+		name string
+		args args
+		want Milliliters
+	}{
+		{name: "Should_ReturnPassedMillilitersPainted_When_ValidParameters", args: args{100000}, want: 2000},
+		{name: "Should_RoundUpMillilitersPainted_When_PartialMilliliter", args: args{51}, want: 2},
+		{name: "Should_ReturnZero_When_ZeroParameters", args: args{0}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calcMillilitersPainted(tt.args.roomArea); got != tt.want {
+				t.Errorf("calcMillilitersPainted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
 
-	roomArea := 10.0
-	roomAreaLitersResult := roomArea / metersPaintedPerLiter
+func Test_calcCans(t *testing.T) {
 	type args struct {
-		roomArea float64
+		volume Milliliters
 	}
 	tests := []struct {
 		name string
 		args args
-		want float64
+		want []Can
 	}{
-		{name: "Should_ReturnPassedLitersPerMeterPainted_When_ValidParameters", args: args{roomArea}, want: roomAreaLitersResult},
+		{name: "Should_ReturnPassedCans_When_ExactVolume", args: args{volume: 3600}, want: []Can{BigCan}},
+		{name: "Should_RoundUpToSmallCan_When_RemainderBelowSmallCan", args: args{volume: 18100}, want: []Can{HugeCan, SmallCan}},
+		{name: "Should_ReturnPassedCans_When_AllSizesNeeded", args: args{volume: 24300}, want: []Can{HugeCan, BigCan, MediumCan, SmallCan}},
+		{name: "Should_ReturnNoCans_When_ZeroVolume", args: args{volume: 0}, want: []Can{}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := calcLitersPerMeterPainted(tt.args.roomArea); got != tt.want {
-				t.Errorf("calcLitersPerMeterPainted() = %v, want %v", got, tt.want)
+			if got := calcCans(tt.args.volume, wallPaintCans); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("calcCans() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -844,8 +867,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 	}{
 		{name: "Should_ReturnPassedPainBudget_When_1WallParameters", args: args{room: Room{Walls: []Wall{
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -863,8 +886,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 
 		{name: "Should_ReturnPassedPainBudget_When_2WallParameters", args: args{room: Room{Walls: []Wall{
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -879,8 +902,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 				},
 			},
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -897,8 +920,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 		}}}, want: []Can{BigCan, BigCan, SmallCan, SmallCan, SmallCan}},
 		{name: "Should_ReturnPassedPainBudget_When_3WallParameters", args: args{room: Room{Walls: []Wall{
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -913,8 +936,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 				},
 			},
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -929,8 +952,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 				},
 			},
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -947,8 +970,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 		}}}, want: []Can{BigCan, BigCan, BigCan, SmallCan, SmallCan, SmallCan, SmallCan}},
 		{name: "Should_ReturnPassedPainBudget_When_4WallParameters", args: args{room: Room{Walls: []Wall{
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -963,8 +986,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 				},
 			},
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -979,8 +1002,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 				},
 			},
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -995,8 +1018,8 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 				},
 			},
 			{
-				Width:  5,
-				Height: 5,
+				Width:  500,
+				Height: 500,
 				Doors: []Door{
 					{
 						Width:  WidthDoor,
@@ -1021,3 +1044,233 @@ func TestPaintBudgetCalculator_CalculatePaintBudget(t *testing.T) {
 		})
 	}
 }
+
+func TestWall_AddBand(t *testing.T) {
+	type fields struct {
+		Width  Centimeters
+		Height Centimeters
+		bands  []Band
+	}
+	type args struct {
+		band Band
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "Should_ReturnPassedBand_When_ValidParameters",
+			fields:  fields{Width: 500, Height: 250},
+			args:    args{band: Band{From: 0, To: 100, Color: "azul"}},
+			wantErr: false,
+		},
+		{
+			name:    "Should_ReturnPassedBand_When_UnpaintedWithoutColor",
+			fields:  fields{Width: 500, Height: 250},
+			args:    args{band: Band{From: 100, To: 250, Unpainted: true}},
+			wantErr: false,
+		},
+		{
+			name:    "Should_BandLimitError_When_BandAboveWallHeight",
+			fields:  fields{Width: 500, Height: 250},
+			args:    args{band: Band{From: 100, To: 300, Color: "azul"}},
+			wantErr: true,
+		},
+		{
+			name:    "Should_BandOrderError_When_FromHigherThanTo",
+			fields:  fields{Width: 500, Height: 250},
+			args:    args{band: Band{From: 200, To: 100, Color: "azul"}},
+			wantErr: true,
+		},
+		{
+			name:    "Should_BandColorError_When_ColorEmpty",
+			fields:  fields{Width: 500, Height: 250},
+			args:    args{band: Band{From: 0, To: 100}},
+			wantErr: true,
+		},
+		{
+			name: "Should_BandOverlapError_When_BandsOverlap",
+			fields: fields{Width: 500, Height: 250, bands: []Band{
+				{From: 0, To: 100, Color: "azul"},
+			}},
+			args:    args{band: Band{From: 90, To: 150, Color: "verde"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Wall{
+				Width:  tt.fields.Width,
+				Height: tt.fields.Height,
+				Bands:  tt.fields.bands,
+			}
+			if err := w.AddBand(tt.args.band); (err != nil) != tt.wantErr {
+				t.Errorf("AddBand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWall_calcBandArea(t *testing.T) {
+	wall := Wall{
+		Width:  500,
+		Height: 250,
+		Doors: []Door{{
+			Width:  WidthDoor,
+			Height: HeightDoor,
+		}},
+		Windows: []Window{{
+			Width:  WidthWindow,
+			Height: HeightWindow,
+			Sill:   SillHeightWindow,
+		}},
+	}
+	type args struct {
+		band Band
+	}
+	tests := []struct {
+		name string
+		args args
+		want SquareCentimeters
+	}{
+		{name: "Should_DiscountDoor_When_BandBelowWindow", args: args{band: Band{From: 0, To: 100}}, want: 42000},
+		{name: "Should_DiscountDoorAndWindow_When_BandCrossesBoth", args: args{band: Band{From: 100, To: 250}}, want: 43800},
+		{name: "Should_ReturnWholeBand_When_NoOpeningCrossed", args: args{band: Band{From: 220, To: 250}}, want: 15000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wall.calcBandArea(tt.args.band); got != tt.want {
+				t.Errorf("calcBandArea() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWall_calcBandArea_OpeningsWiderThanWall(t *testing.T) {
+	wall := Wall{
+		Width:  100,
+		Height: 250,
+		Doors:  []Door{{Width: WidthDoor, Height: HeightDoor}, {Width: WidthDoor, Height: HeightDoor}},
+		Bands:  []Band{{From: 0, To: 100, Color: "azul"}, {From: 100, To: 250, Color: "branco"}},
+	}
+
+	if got := wall.calcBandArea(wall.Bands[0]); got != 0 {
+		t.Errorf("calcBandArea() = %v, want 0", got)
+	}
+	if got, want := wall.calcBandArea(wall.Bands[1]), SquareCentimeters(15000-2*WidthDoor*(HeightDoor-100)); got != want {
+		t.Errorf("calcBandArea() = %v, want %v", got, want)
+	}
+}
+
+func TestPaintBudgetCalculator_CalculatePaintBudgetByColor(t *testing.T) {
+	room := Room{Walls: []Wall{
+		{
+			Width:  500,
+			Height: 500,
+			Bands: []Band{
+				{From: 0, To: 200, Color: "azul"},
+				{From: 400, To: 500, Unpainted: true},
+			},
+		},
+	}}
+
+	p := &PaintBudgetCalculator{}
+	wantByColor := map[string][]Can{"azul": {SmallCan, SmallCan, SmallCan, SmallCan}}
+	if got := p.CalculatePaintBudgetByColor(room); !reflect.DeepEqual(got, wantByColor) {
+		t.Errorf("CalculatePaintBudgetByColor() = %v, want %v", got, wantByColor)
+	}
+
+	wantBase := []Can{SmallCan, SmallCan, SmallCan, SmallCan}
+	if got := p.CalculatePaintBudget(room); !reflect.DeepEqual(got, wantBase) {
+		t.Errorf("CalculatePaintBudget() = %v, want %v", got, wantBase)
+	}
+}
+
+func TestWall_SetTrimLength(t *testing.T) {
+	type args struct {
+		length Centimeters
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{name: "Should_ReturnPassedTrim_When_ValidParameters", args: args{length: 400}, wantErr: false},
+		{name: "Should_ReturnPassedTrim_When_ZeroParameters", args: args{length: 0}, wantErr: false},
+		{name: "Should_TrimNegativeError_When_NegativeLength", args: args{length: -100}, wantErr: true},
+		{name: "Should_TrimLimitError_When_LengthOverWallWidth", args: args{length: 600}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Wall{Width: 500, Height: 250}
+			if err := w.SetTrimLength(tt.args.length); (err != nil) != tt.wantErr {
+				t.Errorf("SetTrimLength() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWall_calcEnamelArea(t *testing.T) {
+	type fields struct {
+		doors      []Door
+		windows    []Window
+		trimLength Centimeters
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   SquareCentimeters
+	}{
+		{
+			name: "Should_ReturnZero_When_NothingPainted",
+			fields: fields{
+				doors:   []Door{{Width: WidthDoor, Height: HeightDoor}},
+				windows: []Window{{Width: WidthWindow, Height: HeightWindow}},
+			},
+			want: 0,
+		},
+		{
+			name: "Should_ReturnPassedEnamelArea_When_DoorsFramesAndTrimPainted",
+			fields: fields{
+				doors:      []Door{{Width: WidthDoor, Height: HeightDoor, Painted: true, FramePainted: true}},
+				windows:    []Window{{Width: WidthWindow, Height: HeightWindow, FramePainted: true}},
+				trimLength: 400,
+			},
+			want: 30400 + 4600 + 3200 + 4000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Wall{
+				Width:      500,
+				Height:     250,
+				Doors:      tt.fields.doors,
+				Windows:    tt.fields.windows,
+				TrimLength: tt.fields.trimLength,
+			}
+			if got := w.calcEnamelArea(); got != tt.want {
+				t.Errorf("calcEnamelArea() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaintBudgetCalculator_CalculateEnamelBudget(t *testing.T) {
+	room := Room{Walls: []Wall{
+		{
+			Width:      500,
+			Height:     250,
+			Doors:      []Door{{Width: WidthDoor, Height: HeightDoor, Painted: true, FramePainted: true}},
+			Windows:    []Window{{Width: WidthWindow, Height: HeightWindow, FramePainted: true}},
+			TrimLength: 400,
+		},
+	}}
+
+	p := &PaintBudgetCalculator{}
+	want := []Can{EnamelSmallCan, EnamelSmallCan}
+	if got := p.CalculateEnamelBudget(room); !reflect.DeepEqual(got, want) {
+		t.Errorf("CalculateEnamelBudget() = %v, want %v", got, want)
+	}
+}
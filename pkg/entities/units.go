@@ -0,0 +1,87 @@
+package entities
+
+import "math"
+
+// Lengths, areas and volumes are kept as integers so that the area and can
+// math is exact. Decimal meters and liters only exist at the API boundary.
+type (
+	Centimeters       int64
+	SquareCentimeters int64
+	Milliliters       int64
+)
+
+const (
+	centimetersPerMeter             = 100
+	squareCentimetersPerSquareMeter = 10000
+	millilitersPerLiter             = 1000
+)
+
+// centimetersTolerance absorbs the float error of decimal meters, such as
+// 2.3 * 100 = 229.99999999999997, without accepting a fraction of centimeter.
+const centimetersTolerance = 1e-6
+
+// maxMeters bounds every measure far above any wall limit, so that converted
+// lengths and the areas computed from them never overflow.
+const maxMeters = 1000
+
+const (
+	measurePrecisionError = "medida invalida: as medidas devem ter no máximo duas casas decimais (centímetros)"
+	measureRangeError     = "medida invalida: as medidas devem ser números de até 1000 metros"
+)
+
+// ParseMeters converts meters to centimeters. Measures finer than a
+// centimeter are rejected instead of rounded, so that rounding cannot move
+// them across a limit, such as a 2.195 m wall becoming tall enough for a door.
+func ParseMeters(meters float64) (Centimeters, error) {
+	if math.IsNaN(meters) || math.Abs(meters) > maxMeters {
+		return 0, NewValidationError(CodeMeasureRange, measureRangeError)
+	}
+
+	centimeters := math.Round(meters * centimetersPerMeter)
+	if math.Abs(meters*centimetersPerMeter-centimeters) > centimetersTolerance {
+		return 0, NewValidationError(CodeMeasurePrecision, measurePrecisionError)
+	}
+	return Centimeters(centimeters), nil
+}
+
+func SquareMetersToSquareCentimeters(squareMeters float64) SquareCentimeters {
+	return SquareCentimeters(math.Round(squareMeters * squareCentimetersPerSquareMeter))
+}
+
+func LitersToMilliliters(liters float64) Milliliters {
+	return Milliliters(math.Round(liters * millilitersPerLiter))
+}
+
+func (c Centimeters) Meters() float64 {
+	return float64(c) / centimetersPerMeter
+}
+
+func (a SquareCentimeters) SquareMeters() float64 {
+	return float64(a) / squareCentimetersPerSquareMeter
+}
+
+func (m Milliliters) Liters() float64 {
+	return float64(m) / millilitersPerLiter
+}
+
+func (c Can) Liters() float64 {
+	return Milliliters(c).Liters()
+}
+
+func calcRectangleArea(width, height Centimeters) SquareCentimeters {
+	return SquareCentimeters(width) * SquareCentimeters(height)
+}
+
+func minCentimeters(a, b Centimeters) Centimeters {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxCentimeters(a, b Centimeters) Centimeters {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseMeters(t *testing.T) {
+	tests := []struct {
+		name     string
+		meters   float64
+		want     Centimeters
+		wantCode string
+	}{
+		{name: "Should_ReturnCentimeters_When_MeasureIsWholeMeters", meters: 3, want: 300},
+		{name: "Should_ReturnCentimeters_When_MeasureHasTwoDecimals", meters: 2.2, want: 220},
+		{name: "Should_IgnoreFloatError_When_MeasureHasTwoDecimals", meters: 2.3, want: 230},
+		{name: "Should_ReturnCentimeters_When_MeasureIsOneCentimeter", meters: 0.01, want: 1},
+		{name: "Should_ReturnCentimeters_When_MeasureIsNegative", meters: -1.25, want: -125},
+		{name: "Should_ReturnError_When_MeasureIsBelowDoorHeightLimit", meters: 2.195, wantCode: CodeMeasurePrecision},
+		{name: "Should_ReturnError_When_MeasureIsAFractionOfCentimeter", meters: 0.001, wantCode: CodeMeasurePrecision},
+		{name: "Should_ReturnError_When_MeasureHasThreeDecimals", meters: 2.501, wantCode: CodeMeasurePrecision},
+		{name: "Should_ReturnError_When_MeasureIsNaN", meters: math.NaN(), wantCode: CodeMeasureRange},
+		{name: "Should_ReturnError_When_MeasureIsInfinite", meters: math.Inf(1), wantCode: CodeMeasureRange},
+		{name: "Should_ReturnError_When_MeasureIsNegativeInfinite", meters: math.Inf(-1), wantCode: CodeMeasureRange},
+		{name: "Should_ReturnError_When_MeasureIsHuge", meters: 1e300, wantCode: CodeMeasureRange},
+		{name: "Should_ReturnError_When_MeasureIsAboveMaximum", meters: 1000.01, wantCode: CodeMeasureRange},
+		{name: "Should_ReturnCentimeters_When_MeasureIsTheMaximum", meters: 1000, want: 100000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMeters(tt.meters)
+			if code := ValidationCode(err); code != tt.wantCode {
+				t.Fatalf("ParseMeters() error = %v, want code %q", err, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMeters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
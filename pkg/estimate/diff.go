@@ -0,0 +1,160 @@
+package estimate
+
+import (
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/paint"
+	"reflect"
+	"sort"
+)
+
+// RevisionDiff explains what changed between two revisions of an estimate.
+// Walls are matched by their position in the room; deltas are To minus From.
+type RevisionDiff struct {
+	From         int              `json:"from"`
+	To           int              `json:"to"`
+	WallsAdded   []WallChange     `json:"walls_added"`
+	WallsRemoved []WallChange     `json:"walls_removed"`
+	WallsChanged []WallChange     `json:"walls_changed"`
+	AreaDelta    float64          `json:"area_delta"`
+	LitersDelta  float64          `json:"liters_delta"`
+	CansDelta    CansDelta        `json:"cans_delta"`
+	BandsDelta   []BandCansDelta  `json:"bands_delta,omitempty"`
+	EnamelDelta  *EnamelCansDelta `json:"enamel_delta,omitempty"`
+}
+
+type WallChange struct {
+	Index int              `json:"index"`
+	From  *paint.WallInput `json:"from,omitempty"`
+	To    *paint.WallInput `json:"to,omitempty"`
+}
+
+type CansDelta struct {
+	ExtraLargeCan int64 `json:"huge_can"`
+	LargeCan      int64 `json:"big_can"`
+	MediumCan     int64 `json:"medium_can"`
+	SmallCan      int64 `json:"small_can"`
+}
+
+type BandCansDelta struct {
+	Color string `json:"color"`
+	CansDelta
+}
+
+type EnamelCansDelta struct {
+	LargeCan  int64 `json:"big_can"`
+	MediumCan int64 `json:"medium_can"`
+	SmallCan  int64 `json:"small_can"`
+}
+
+func Diff(from, to Revision) RevisionDiff {
+	diff := RevisionDiff{
+		From:         from.Number,
+		To:           to.Number,
+		WallsAdded:   []WallChange{},
+		WallsRemoved: []WallChange{},
+		WallsChanged: []WallChange{},
+	}
+
+	fromWalls := from.Input.Walls
+	toWalls := to.Input.Walls
+	for i := 0; i < len(fromWalls) || i < len(toWalls); i++ {
+		switch {
+		case i >= len(fromWalls):
+			diff.WallsAdded = append(diff.WallsAdded, WallChange{Index: i, To: &toWalls[i]})
+
+		case i >= len(toWalls):
+			diff.WallsRemoved = append(diff.WallsRemoved, WallChange{Index: i, From: &fromWalls[i]})
+
+		case !isSameWall(fromWalls[i], toWalls[i]):
+			diff.WallsChanged = append(diff.WallsChanged, WallChange{Index: i, From: &fromWalls[i], To: &toWalls[i]})
+		}
+	}
+
+	diff.AreaDelta = squareMetersDelta(from.Result.Area, to.Result.Area)
+	diff.LitersDelta = litersDelta(from.Result.Liters, to.Result.Liters)
+	diff.CansDelta = CansDelta{
+		ExtraLargeCan: to.Result.ExtraLargeCan - from.Result.ExtraLargeCan,
+		LargeCan:      to.Result.LargeCan - from.Result.LargeCan,
+		MediumCan:     to.Result.MediumCan - from.Result.MediumCan,
+		SmallCan:      to.Result.SmallCan - from.Result.SmallCan,
+	}
+	diff.BandsDelta = bandsDelta(from.Result.Bands, to.Result.Bands)
+	diff.EnamelDelta = enamelDelta(from.Result.Enamel, to.Result.Enamel)
+
+	return diff
+}
+
+func isSameWall(from, to paint.WallInput) bool {
+	if len(from.Bands) == 0 && len(to.Bands) == 0 {
+		from.Bands, to.Bands = nil, nil
+	}
+
+	return reflect.DeepEqual(from, to)
+}
+
+// squareMetersDelta and litersDelta subtract in the integer units used by the
+// calculation so the delta of two decimals carries no float noise.
+func squareMetersDelta(from, to float64) float64 {
+	return (entities.SquareMetersToSquareCentimeters(to) - entities.SquareMetersToSquareCentimeters(from)).SquareMeters()
+}
+
+func litersDelta(from, to float64) float64 {
+	return (entities.LitersToMilliliters(to) - entities.LitersToMilliliters(from)).Liters()
+}
+
+func bandsDelta(from, to []paint.BandPaintOutput) []BandCansDelta {
+	byColor := map[string]*BandCansDelta{}
+	delta := func(color string) *BandCansDelta {
+		if byColor[color] == nil {
+			byColor[color] = &BandCansDelta{Color: color}
+		}
+		return byColor[color]
+	}
+
+	for _, band := range from {
+		d := delta(band.Color)
+		d.ExtraLargeCan -= band.ExtraLargeCan
+		d.LargeCan -= band.LargeCan
+		d.MediumCan -= band.MediumCan
+		d.SmallCan -= band.SmallCan
+	}
+	for _, band := range to {
+		d := delta(band.Color)
+		d.ExtraLargeCan += band.ExtraLargeCan
+		d.LargeCan += band.LargeCan
+		d.MediumCan += band.MediumCan
+		d.SmallCan += band.SmallCan
+	}
+
+	bands := []BandCansDelta{}
+	for _, d := range byColor {
+		if d.CansDelta != (CansDelta{}) {
+			bands = append(bands, *d)
+		}
+	}
+
+	sort.Slice(bands, func(i, j int) bool {
+		return bands[i].Color < bands[j].Color
+	})
+	return bands
+}
+
+func enamelDelta(from, to *paint.EnamelPaintOutput) *EnamelCansDelta {
+	if from == nil {
+		from = &paint.EnamelPaintOutput{}
+	}
+	if to == nil {
+		to = &paint.EnamelPaintOutput{}
+	}
+
+	d := EnamelCansDelta{
+		LargeCan:  to.LargeCan - from.LargeCan,
+		MediumCan: to.MediumCan - from.MediumCan,
+		SmallCan:  to.SmallCan - from.SmallCan,
+	}
+	if d == (EnamelCansDelta{}) {
+		return nil
+	}
+
+	return &d
+}
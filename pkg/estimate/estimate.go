@@ -0,0 +1,85 @@
+package estimate
+
+import (
+	"crypto/rand"
+	"digitalrepublic/pkg/paint"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+const (
+	notFoundError         = "orçamento não encontrado"
+	revisionNotFoundError = "revisão do orçamento não encontrada"
+)
+
+var (
+	ErrNotFound         = errors.New(notFoundError)
+	ErrRevisionNotFound = errors.New(revisionNotFoundError)
+)
+
+// Estimate is a saved calculation: the room sent by the salesperson and the
+// cans computed for it. Input and Result always mirror the latest revision.
+type Estimate struct {
+	ID        string                               `json:"id"`
+	Input     paint.CalculateRoomPaintInCansInput  `json:"input"`
+	Result    paint.CalculateRoomPaintInCansOutput `json:"result"`
+	Revisions []Revision                           `json:"revisions"`
+	CreatedAt time.Time                            `json:"created_at"`
+	UpdatedAt time.Time                            `json:"updated_at"`
+}
+
+// Revision is an immutable snapshot of what was quoted, numbered from 1.
+type Revision struct {
+	Number    int                                  `json:"number"`
+	Input     paint.CalculateRoomPaintInCansInput  `json:"input"`
+	Result    paint.CalculateRoomPaintInCansOutput `json:"result"`
+	CreatedAt time.Time                            `json:"created_at"`
+}
+
+// Repository stores estimates. Create assigns the ID and timestamps and
+// records the first revision. Update keeps the creation time and the revisions
+// already stored, appending a new one; revisions sent by the caller are ignored.
+// Ping reports whether the storage can be written, without reading the
+// estimates, so that it stays cheap however many are stored.
+type Repository interface {
+	Create(estimate Estimate) (Estimate, error)
+	Get(id string) (Estimate, error)
+	List() ([]Estimate, error)
+	Update(estimate Estimate) (Estimate, error)
+	Delete(id string) error
+	Ping() error
+}
+
+func (e Estimate) Revision(number int) (Revision, error) {
+	if number < 1 || number > len(e.Revisions) {
+		return Revision{}, ErrRevisionNotFound
+	}
+
+	return e.Revisions[number-1], nil
+}
+
+// LatestRevision returns the last revision, or ErrRevisionNotFound for an
+// estimate without revisions.
+func (e Estimate) LatestRevision() (Revision, error) {
+	return e.Revision(len(e.Revisions))
+}
+
+func newRevision(estimate Estimate, number int) Revision {
+	return Revision{
+		Number:    number,
+		Input:     estimate.Input,
+		Result:    estimate.Result,
+		CreatedAt: estimate.UpdatedAt,
+	}
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
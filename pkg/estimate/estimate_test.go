@@ -0,0 +1,276 @@
+package estimate
+
+import (
+	"digitalrepublic/pkg/paint"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newEstimate() Estimate {
+	return Estimate{
+		Input: paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{
+			{Width: 5, Height: 5, DoorQuantity: 1, WindowQuantity: 1},
+		}},
+		Result: paint.CalculateRoomPaintInCansOutput{LargeCan: 1, SmallCan: 2},
+	}
+}
+
+func testRepository(t *testing.T, repository Repository) {
+	created, err := repository.Create(newEstimate())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.ID == "" || created.CreatedAt.IsZero() || !created.CreatedAt.Equal(created.UpdatedAt) {
+		t.Errorf("Create() = %+v, want ID and timestamps", created)
+	}
+
+	got, err := repository.Get(created.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, created) {
+		t.Errorf("Get() = %+v, want %+v", got, created)
+	}
+
+	changed := created
+	changed.Result = paint.CalculateRoomPaintInCansOutput{LargeCan: 2}
+	updated, err := repository.Update(changed)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !updated.CreatedAt.Equal(created.CreatedAt) || updated.Result.LargeCan != 2 {
+		t.Errorf("Update() = %+v, want created_at kept and result changed", updated)
+	}
+
+	list, err := repository.List()
+	if err != nil || len(list) != 1 {
+		t.Errorf("List() = %v, %v, want one estimate", list, err)
+	}
+
+	err = repository.Delete(created.ID)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	_, err = repository.Get(created.ID)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, ErrNotFound)
+	}
+	_, err = repository.Update(created)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update() after Delete() error = %v, want %v", err, ErrNotFound)
+	}
+	err = repository.Delete(created.ID)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() after Delete() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestMemoryRepository(t *testing.T) {
+	testRepository(t, NewMemoryRepository())
+}
+
+func TestFileRepository(t *testing.T) {
+	repository, err := NewFileRepository(filepath.Join(t.TempDir(), "estimates.json"))
+	if err != nil {
+		t.Fatalf("NewFileRepository() error = %v", err)
+	}
+	testRepository(t, repository)
+}
+
+func TestFileRepository_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "estimates.json")
+
+	repository, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileRepository() error = %v", err)
+	}
+	created, err := repository.Create(newEstimate())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	reopened, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileRepository() error = %v", err)
+	}
+	got, err := reopened.Get(created.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.ID != created.ID || !got.CreatedAt.Equal(created.CreatedAt) || !reflect.DeepEqual(got.Input, created.Input) {
+		t.Errorf("Get() = %+v, want %+v", got, created)
+	}
+}
+
+func TestFileRepository_LoadWithoutRevisions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "estimates.json")
+	saved := `[{"id": "antigo", "input": {"walls": [{"width": 5, "height": 2.5}]}, "result": {"medium_can": 1}, "updated_at": "2022-11-01T10:00:00Z"}]`
+	if err := os.WriteFile(path, []byte(saved), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	repository, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileRepository() error = %v", err)
+	}
+	got, err := repository.Get("antigo")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	latest, err := got.LatestRevision()
+	if err != nil {
+		t.Fatalf("LatestRevision() error = %v", err)
+	}
+	if latest.Number != 1 || latest.Result.MediumCan != 1 || !latest.CreatedAt.Equal(got.UpdatedAt) || !reflect.DeepEqual(latest.Input, got.Input) {
+		t.Errorf("LatestRevision() = %+v, want revision 1 with the stored input and result", latest)
+	}
+}
+
+func TestFileRepository_WriteFails(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "estimates")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	repository, err := NewFileRepository(filepath.Join(dir, "estimates.json"))
+	if err != nil {
+		t.Fatalf("NewFileRepository() error = %v", err)
+	}
+	created, err := repository.Create(newEstimate())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := repository.Ping(); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	// Without the directory the temporary file cannot be created.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.Ping(); err == nil {
+		t.Error("Ping() error = nil, want the missing directory")
+	}
+
+	if _, err := repository.Create(newEstimate()); err == nil {
+		t.Error("Create() error = nil, want the write error")
+	}
+	changed := created
+	changed.Result = paint.CalculateRoomPaintInCansOutput{LargeCan: 2}
+	if _, err := repository.Update(changed); err == nil {
+		t.Error("Update() error = nil, want the write error")
+	}
+	if err := repository.Delete(created.ID); err == nil {
+		t.Error("Delete() error = nil, want the write error")
+	}
+
+	list, _ := repository.List()
+	if len(list) != 1 || !reflect.DeepEqual(list[0], created) {
+		t.Errorf("List() = %+v, want only the estimate written before, unchanged", list)
+	}
+}
+
+func TestMemoryRepository_ListOrder(t *testing.T) {
+	repository := newMemoryRepository()
+	clock := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+	repository.now = func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+
+	first, _ := repository.Create(newEstimate())
+	second, _ := repository.Create(newEstimate())
+
+	list, _ := repository.List()
+	if len(list) != 2 || list[0].ID != first.ID || list[1].ID != second.ID {
+		t.Errorf("List() = %v, want oldest first", list)
+	}
+}
+
+func TestMemoryRepository_Revisions(t *testing.T) {
+	repository := NewMemoryRepository()
+
+	created, _ := repository.Create(newEstimate())
+	if len(created.Revisions) != 1 || created.Revisions[0].Number != 1 {
+		t.Fatalf("Create() revisions = %v, want revision 1", created.Revisions)
+	}
+
+	changed := created
+	changed.Result = paint.CalculateRoomPaintInCansOutput{LargeCan: 2}
+	changed.Revisions = nil
+	updated, err := repository.Update(changed)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if len(updated.Revisions) != 2 {
+		t.Fatalf("Update() revisions = %v, want 2 revisions", updated.Revisions)
+	}
+
+	first, err := updated.Revision(1)
+	if err != nil || !reflect.DeepEqual(first.Result, created.Result) {
+		t.Errorf("Revision(1) = %+v, %v, want the created result", first, err)
+	}
+	latest, err := updated.LatestRevision()
+	if err != nil || latest.Result.LargeCan != 2 {
+		t.Errorf("LatestRevision() = %+v, %v, want the updated result", latest, err)
+	}
+	_, err = updated.Revision(3)
+	if !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("Revision(3) error = %v, want %v", err, ErrRevisionNotFound)
+	}
+	_, err = Estimate{}.LatestRevision()
+	if !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("LatestRevision() without revisions error = %v, want %v", err, ErrRevisionNotFound)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	wall := paint.WallInput{Width: 5, Height: 5, DoorQuantity: 1, WindowQuantity: 1}
+	changedWall := paint.WallInput{Width: 4, Height: 5, Bands: []paint.BandInput{}}
+	addedWall := paint.WallInput{Width: 3, Height: 3}
+
+	from := Revision{
+		Number: 1,
+		Input:  paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{wall, wall}},
+		Result: paint.CalculateRoomPaintInCansOutput{
+			LargeCan: 2, SmallCan: 3, Area: 42.16, Liters: 8.432,
+			Bands: []paint.BandPaintOutput{{Color: "azul", SmallCan: 2}},
+		},
+	}
+	to := Revision{
+		Number: 2,
+		Input:  paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{wall, changedWall, addedWall}},
+		Result: paint.CalculateRoomPaintInCansOutput{
+			LargeCan: 3, SmallCan: 1, Area: 50.08, Liters: 10.016,
+			Enamel: &paint.EnamelPaintOutput{SmallCan: 1},
+		},
+	}
+
+	want := RevisionDiff{
+		From:         1,
+		To:           2,
+		WallsAdded:   []WallChange{{Index: 2, To: &to.Input.Walls[2]}},
+		WallsRemoved: []WallChange{},
+		WallsChanged: []WallChange{{Index: 1, From: &from.Input.Walls[1], To: &to.Input.Walls[1]}},
+		AreaDelta:    7.92,
+		LitersDelta:  1.584,
+		CansDelta:    CansDelta{LargeCan: 1, SmallCan: -2},
+		BandsDelta:   []BandCansDelta{{Color: "azul", CansDelta: CansDelta{SmallCan: -2}}},
+		EnamelDelta:  &EnamelCansDelta{SmallCan: 1},
+	}
+	if got := Diff(from, to); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+
+	same := Diff(from, from)
+	if len(same.WallsChanged) != 0 || same.AreaDelta != 0 || same.EnamelDelta != nil || len(same.BandsDelta) != 0 {
+		t.Errorf("Diff() of the same revision = %+v, want no changes", same)
+	}
+}
@@ -0,0 +1,164 @@
+package estimate
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fileRepository keeps every estimate in memory and rewrites the whole JSON
+// file on each change, storing the change in memory only once the file is
+// written, so a failed write never leaves both apart. The file is replaced
+// atomically so a crash never leaves it half written. writeMu serializes
+// changes; reads go to memory.
+type fileRepository struct {
+	*memoryRepository
+	path    string
+	writeMu sync.Mutex
+}
+
+func NewFileRepository(path string) (Repository, error) {
+	r := &fileRepository{
+		memoryRepository: newMemoryRepository(),
+		path:             path,
+	}
+
+	err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *fileRepository) Create(estimate Estimate) (Estimate, error) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	created, err := r.memoryRepository.created(estimate)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	err = r.commit(created.ID, &created)
+	if err != nil {
+		return Estimate{}, err
+	}
+	return created, nil
+}
+
+func (r *fileRepository) Update(estimate Estimate) (Estimate, error) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	r.mu.RLock()
+	updated, err := r.memoryRepository.updated(estimate)
+	r.mu.RUnlock()
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	err = r.commit(updated.ID, &updated)
+	if err != nil {
+		return Estimate{}, err
+	}
+	return updated, nil
+}
+
+func (r *fileRepository) Delete(id string) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	_, err := r.memoryRepository.Get(id)
+	if err != nil {
+		return err
+	}
+
+	return r.commit(id, nil)
+}
+
+// commit writes the file with the estimate id replaced by estimate, or
+// removed when estimate is nil, and only then stores the change in memory.
+func (r *fileRepository) commit(id string, estimate *Estimate) error {
+	err := r.save(r.snapshot(func(estimates map[string]Estimate) {
+		if estimate == nil {
+			delete(estimates, id)
+			return
+		}
+		estimates[id] = *estimate
+	}))
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if estimate == nil {
+		delete(r.estimates, id)
+		return nil
+	}
+	r.estimates[id] = *estimate
+	return nil
+}
+
+// Ping checks that the directory the file is replaced in still exists.
+func (r *fileRepository) Ping() error {
+	_, err := os.Stat(filepath.Dir(r.path))
+	return err
+}
+
+func (r *fileRepository) load() error {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var estimates []Estimate
+	err = json.Unmarshal(data, &estimates)
+	if err != nil {
+		return err
+	}
+
+	for _, estimate := range estimates {
+		// Files written before revisions existed hold only the estimate,
+		// which becomes its first revision.
+		if len(estimate.Revisions) == 0 {
+			estimate.Revisions = []Revision{newRevision(estimate, 1)}
+		}
+		r.estimates[estimate.ID] = estimate
+	}
+
+	return nil
+}
+
+func (r *fileRepository) save(estimates []Estimate) error {
+	data, err := json.MarshalIndent(estimates, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), r.path)
+}
@@ -0,0 +1,150 @@
+package estimate
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryRepository struct {
+	mu        sync.RWMutex
+	estimates map[string]Estimate
+	now       func() time.Time
+}
+
+func NewMemoryRepository() Repository {
+	return newMemoryRepository()
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		estimates: map[string]Estimate{},
+		now:       time.Now,
+	}
+}
+
+func (r *memoryRepository) Create(estimate Estimate) (Estimate, error) {
+	created, err := r.created(estimate)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.estimates[created.ID] = created
+	return created, nil
+}
+
+// created returns estimate with its ID, timestamps and first revision,
+// without storing it.
+func (r *memoryRepository) created(estimate Estimate) (Estimate, error) {
+	id, err := newID()
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	now := r.now().UTC()
+	estimate.ID = id
+	estimate.CreatedAt = now
+	estimate.UpdatedAt = now
+	estimate.Revisions = []Revision{newRevision(estimate, 1)}
+
+	return estimate, nil
+}
+
+func (r *memoryRepository) Get(id string) (Estimate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	estimate, ok := r.estimates[id]
+	if !ok {
+		return Estimate{}, ErrNotFound
+	}
+
+	return estimate, nil
+}
+
+func (r *memoryRepository) List() ([]Estimate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sorted(r.estimates), nil
+}
+
+// snapshot returns the estimates stored with change applied, in the order of
+// List, leaving the stored ones untouched.
+func (r *memoryRepository) snapshot(change func(estimates map[string]Estimate)) []Estimate {
+	r.mu.RLock()
+	estimates := make(map[string]Estimate, len(r.estimates)+1)
+	for id, estimate := range r.estimates {
+		estimates[id] = estimate
+	}
+	r.mu.RUnlock()
+
+	change(estimates)
+	return sorted(estimates)
+}
+
+// sorted lists estimates oldest first.
+func sorted(byID map[string]Estimate) []Estimate {
+	estimates := make([]Estimate, 0, len(byID))
+	for _, estimate := range byID {
+		estimates = append(estimates, estimate)
+	}
+
+	sort.Slice(estimates, func(i, j int) bool {
+		if estimates[i].CreatedAt.Equal(estimates[j].CreatedAt) {
+			return estimates[i].ID < estimates[j].ID
+		}
+		return estimates[i].CreatedAt.Before(estimates[j].CreatedAt)
+	})
+	return estimates
+}
+
+func (r *memoryRepository) Update(estimate Estimate) (Estimate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updated, err := r.updated(estimate)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	r.estimates[updated.ID] = updated
+	return updated, nil
+}
+
+// updated returns estimate with the creation time and revisions of the stored
+// one and a new revision appended, without storing it. r.mu must be held.
+func (r *memoryRepository) updated(estimate Estimate) (Estimate, error) {
+	actual, ok := r.estimates[estimate.ID]
+	if !ok {
+		return Estimate{}, ErrNotFound
+	}
+
+	estimate.CreatedAt = actual.CreatedAt
+	estimate.UpdatedAt = r.now().UTC()
+
+	revisions := make([]Revision, len(actual.Revisions), len(actual.Revisions)+1)
+	copy(revisions, actual.Revisions)
+	estimate.Revisions = append(revisions, newRevision(estimate, len(actual.Revisions)+1))
+
+	return estimate, nil
+}
+
+func (r *memoryRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.estimates[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.estimates, id)
+	return nil
+}
+
+func (r *memoryRepository) Ping() error {
+	return nil
+}
@@ -0,0 +1,99 @@
+package exporter
+
+import (
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/paint"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	unsupportedFormatError = "formato de exportação não suportado: use json, csv ou xlsx"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New(unsupportedFormatError)
+
+// Exporter writes a calculation result in one file format.
+type Exporter interface {
+	ContentType() string
+	Extension() string
+	Export(w io.Writer, result paint.CalculateRoomPaintInCansOutput) error
+}
+
+var exporters = map[string]Exporter{
+	FormatJSON: jsonExporter{},
+	FormatCSV:  csvExporter{},
+	FormatXLSX: xlsxExporter{},
+}
+
+// Formats lists the supported formats, the default one first.
+var Formats = []string{FormatJSON, FormatCSV, FormatXLSX}
+
+func New(format string) (Exporter, error) {
+	exporter, ok := exporters[strings.ToLower(format)]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	return exporter, nil
+}
+
+// ContentTypes maps the media type of each format to its name, for content
+// negotiation with the Accept header.
+func ContentTypes() map[string]string {
+	types := map[string]string{}
+	for format, exporter := range exporters {
+		types[exporter.ContentType()] = format
+	}
+	return types
+}
+
+// CanLine is one kind of can in the result, e.g. two 0.5L cans of the blue band.
+type CanLine struct {
+	Paint    string
+	Size     entities.Can
+	Quantity int64
+}
+
+const (
+	wallPaint   = "parede"
+	enamelPaint = "esmalte"
+)
+
+// CanLines flattens the can counts of a result into one line per paint and
+// size, leaving out the sizes that are not needed.
+func CanLines(result paint.CalculateRoomPaintInCansOutput) []CanLine {
+	var lines []CanLine
+	add := func(name string, size entities.Can, quantity int64) {
+		if quantity > 0 {
+			lines = append(lines, CanLine{Paint: name, Size: size, Quantity: quantity})
+		}
+	}
+
+	add(wallPaint, entities.HugeCan, result.ExtraLargeCan)
+	add(wallPaint, entities.BigCan, result.LargeCan)
+	add(wallPaint, entities.MediumCan, result.MediumCan)
+	add(wallPaint, entities.SmallCan, result.SmallCan)
+
+	for _, band := range result.Bands {
+		add(band.Color, entities.HugeCan, band.ExtraLargeCan)
+		add(band.Color, entities.BigCan, band.LargeCan)
+		add(band.Color, entities.MediumCan, band.MediumCan)
+		add(band.Color, entities.SmallCan, band.SmallCan)
+	}
+
+	if result.Enamel != nil {
+		add(enamelPaint, entities.EnamelBigCan, result.Enamel.LargeCan)
+		add(enamelPaint, entities.EnamelMediumCan, result.Enamel.MediumCan)
+		add(enamelPaint, entities.EnamelSmallCan, result.Enamel.SmallCan)
+	}
+
+	return lines
+}
@@ -0,0 +1,89 @@
+package exporter
+
+import (
+	"bytes"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/xlsx"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var result = paint.CalculateRoomPaintInCansOutput{
+	LargeCan: 1,
+	SmallCan: 2,
+	Bands:    []paint.BandPaintOutput{{Color: "azul", SmallCan: 1}},
+	Enamel:   &paint.EnamelPaintOutput{SmallCan: 2},
+	Area:     25.08,
+	Liters:   5.016,
+	Walls:    []paint.WallPaintOutput{{Area: 21.08, Liters: 4.216}, {Area: 4, Liters: 0.8}},
+}
+
+func export(t *testing.T, format string) []byte {
+	t.Helper()
+
+	exporter, err := New(format)
+	if err != nil {
+		t.Fatalf("New(%q) error = %v", format, err)
+	}
+
+	var out bytes.Buffer
+	if err := exporter.Export(&out, result); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	return out.Bytes()
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("XLSX"); err != nil {
+		t.Errorf("New() error = %v, want nil", err)
+	}
+	if _, err := New("pdf"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("New() error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestJSONExporter(t *testing.T) {
+	var got paint.CalculateRoomPaintInCansOutput
+	if err := json.Unmarshal(export(t, FormatJSON), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, result) {
+		t.Errorf("Export() = %+v, want %+v", got, result)
+	}
+}
+
+func TestCSVExporter(t *testing.T) {
+	want := "type,item,area,liters,can_size,quantity\n" +
+		"wall,1,21.08,4.216,,\n" +
+		"wall,2,4,0.8,,\n" +
+		"can,parede,,,3.6,1\n" +
+		"can,parede,,,0.5,2\n" +
+		"can,azul,,,0.5,1\n" +
+		"can,esmalte,,,0.225,2\n" +
+		"total,,25.08,5.016,,\n"
+
+	if got := string(export(t, FormatCSV)); got != want {
+		t.Errorf("Export() = %v, want %v", got, want)
+	}
+}
+
+func TestXLSXExporter(t *testing.T) {
+	data := export(t, FormatXLSX)
+
+	rows, err := xlsx.ReadRows(bytes.NewReader(data), int64(len(data)), xlsx.Limits{Rows: 10, Columns: 10, PartSize: 1 << 20})
+	if err != nil {
+		t.Fatalf("ReadRows() error = %v", err)
+	}
+
+	want := []xlsx.Row{
+		{Number: 1, Cells: []string{"Parede", "Área (m²)", "Litros"}},
+		{Number: 2, Cells: []string{"1", "21.08", "4.216"}},
+		{Number: 3, Cells: []string{"2", "4", "0.8"}},
+		{Number: 4, Cells: []string{"Total", "25.08", "5.016"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("ReadRows() = %+v, want %+v", rows, want)
+	}
+}
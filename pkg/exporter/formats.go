@@ -0,0 +1,90 @@
+package exporter
+
+import (
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/xlsx"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+type jsonExporter struct{}
+
+func (jsonExporter) ContentType() string {
+	return "application/json"
+}
+
+func (jsonExporter) Extension() string {
+	return FormatJSON
+}
+
+func (jsonExporter) Export(w io.Writer, result paint.CalculateRoomPaintInCansOutput) error {
+	return json.NewEncoder(w).Encode(result)
+}
+
+// csvExporter writes a single table so purchasing systems can ingest it
+// without knowing about sections: one row per wall, one per can line and a
+// total row, told apart by the type column.
+type csvExporter struct{}
+
+var csvHeader = []string{"type", "item", "area", "liters", "can_size", "quantity"}
+
+func (csvExporter) ContentType() string {
+	return "text/csv"
+}
+
+func (csvExporter) Extension() string {
+	return FormatCSV
+}
+
+func (csvExporter) Export(w io.Writer, result paint.CalculateRoomPaintInCansOutput) error {
+	return csv.NewWriter(w).WriteAll(CSVRecords(result))
+}
+
+// CSVRecords returns the rows written by the CSV format, header included.
+func CSVRecords(result paint.CalculateRoomPaintInCansOutput) [][]string {
+	records := [][]string{csvHeader}
+	for i, wall := range result.Walls {
+		records = append(records, []string{"wall", strconv.Itoa(i + 1), formatFloat(wall.Area), formatFloat(wall.Liters), "", ""})
+	}
+	for _, line := range CanLines(result) {
+		records = append(records, []string{"can", line.Paint, "", "", formatFloat(line.Size.Liters()), strconv.FormatInt(line.Quantity, 10)})
+	}
+	records = append(records, []string{"total", "", formatFloat(result.Area), formatFloat(result.Liters), "", ""})
+
+	return records
+}
+
+// xlsxExporter writes the wall breakdown and the can list on separate sheets.
+type xlsxExporter struct{}
+
+func (xlsxExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (xlsxExporter) Extension() string {
+	return FormatXLSX
+}
+
+func (xlsxExporter) Export(w io.Writer, result paint.CalculateRoomPaintInCansOutput) error {
+	walls := [][]interface{}{{"Parede", "Área (m²)", "Litros"}}
+	for i, wall := range result.Walls {
+		walls = append(walls, []interface{}{i + 1, wall.Area, wall.Liters})
+	}
+	walls = append(walls, []interface{}{"Total", result.Area, result.Liters})
+
+	cans := [][]interface{}{{"Tinta", "Lata (L)", "Quantidade"}}
+	for _, line := range CanLines(result) {
+		cans = append(cans, []interface{}{line.Paint, line.Size.Liters(), line.Quantity})
+	}
+
+	return xlsx.WriteSheets(w, []xlsx.Sheet{
+		{Name: "Paredes", Rows: walls},
+		{Name: "Latas", Rows: cans},
+	})
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
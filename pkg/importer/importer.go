@@ -0,0 +1,362 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/xlsx"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	emptyFileError       = "arquivo vazio: é necessario um cabeçalho e pelo menos uma parede"
+	missingColumnError   = "coluna obrigatória ausente"
+	duplicateColumnError = "coluna repetida no cabeçalho"
+	emptyValueError      = "valor obrigatório não informado"
+	invalidNumberError   = "valor numérico invalido"
+	invalidIntegerError  = "valor inteiro invalido"
+	duplicateWallError   = "parede repetida no mesmo ambiente"
+	rowLimitError        = "arquivo grande demais: são aceitas até 4000 paredes"
+)
+
+// MaxRows is the largest number of walls read from one file, enough for the
+// rooms of a full batch with 4 walls each.
+const MaxRows = 4 * paint.MaxBatchSize
+
+// xlsxLimits bound what is read from a workbook: the header and the walls,
+// a few spare columns and XML parts far larger than such a sheet needs.
+var xlsxLimits = xlsx.Limits{Rows: MaxRows + 1, Columns: 64, PartSize: 32 << 20}
+
+// Columns expected in the header row, in any order and case.
+const (
+	ColumnRoom    = "room"
+	ColumnWall    = "wall"
+	ColumnWidth   = "width"
+	ColumnHeight  = "height"
+	ColumnDoors   = "doors"
+	ColumnWindows = "windows"
+)
+
+var requiredColumns = []string{ColumnRoom, ColumnWall, ColumnWidth, ColumnHeight, ColumnDoors, ColumnWindows}
+
+// fieldColumns are the columns the wall fields pointed at by calculation
+// errors were read from.
+var fieldColumns = map[string]string{
+	"width":           ColumnWidth,
+	"height":          ColumnHeight,
+	"door_quantity":   ColumnDoors,
+	"window_quantity": ColumnWindows,
+}
+
+// Room is one room of the imported file, with its walls in the order they
+// appear. Walls keep the labels given in the wall column.
+type Room struct {
+	Name  string                              `json:"name"`
+	Walls []string                            `json:"walls"`
+	Input paint.CalculateRoomPaintInCansInput `json:"input"`
+}
+
+// CellError points at the cell that could not be imported. Row and Column
+// count from 1 like the spreadsheet does; Column is 0 when there is no cell
+// to point at, e.g. when the wall itself is invalid or a column is missing.
+type CellError struct {
+	Row    int    `json:"row"`
+	Column int    `json:"column"`
+	Field  string `json:"field,omitempty"`
+	Err    error  `json:"-"`
+}
+
+func (e *CellError) Error() string {
+	if e.Column == 0 && e.Field == "" {
+		return fmt.Sprintf("linha %d: %s", e.Row, e.Err)
+	}
+	if e.Column == 0 {
+		return fmt.Sprintf("linha %d (%s): %s", e.Row, e.Field, e.Err)
+	}
+
+	return fmt.Sprintf("linha %d, coluna %s (%s): %s", e.Row, xlsx.ColumnName(e.Column-1), e.Field, e.Err)
+}
+
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+func (e *CellError) MarshalJSON() ([]byte, error) {
+	type cellError CellError
+	return json.Marshal(struct {
+		*cellError
+		Message string `json:"message"`
+	}{(*cellError)(e), e.Err.Error()})
+}
+
+// Calculate checks the room as each wall is read, so a wall breaking a rule of
+// the calculation points at its own row. It is the calculation the server is
+// configured with, such as Service.Calculate.
+type Calculate func(ctx context.Context, room paint.CalculateRoomPaintInCansInput) (*paint.CalculateRoomPaintInCansOutput, error)
+
+// Errors gathers every problem of the file so it can be fixed in one pass.
+type Errors []*CellError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ReadCSV imports a CSV file. Both comma and semicolon separated files are
+// accepted, and decimals may use either a point or a comma.
+func ReadCSV(ctx context.Context, r io.Reader, calculate Calculate) ([]Room, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectSeparator(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			return nil, Errors{{Row: parseError.Line, Column: parseError.Column, Err: parseError.Err}}
+		}
+		return nil, err
+	}
+
+	records := make([]record, len(rows))
+	for i, row := range rows {
+		records[i] = record{line: i + 1, cells: row}
+	}
+	return readRecords(ctx, records, calculate)
+}
+
+// ReadXLSX imports the first sheet of an XLSX workbook.
+func ReadXLSX(ctx context.Context, r io.ReaderAt, size int64, calculate Calculate) ([]Room, error) {
+	rows, err := xlsx.ReadRows(r, size, xlsxLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]record, len(rows))
+	for i, row := range rows {
+		records[i] = record{line: row.Number, cells: row.Cells}
+	}
+	return readRecords(ctx, records, calculate)
+}
+
+func detectSeparator(data []byte) rune {
+	header := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		header = data[:i]
+	}
+
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+// record is a row of the file with the line it is shown at.
+type record struct {
+	line  int
+	cells []string
+}
+
+func readRecords(ctx context.Context, records []record, calculate Calculate) ([]Room, error) {
+	if len(records) < 2 {
+		return nil, errors.New(emptyFileError)
+	}
+	if len(records) > MaxRows+1 {
+		return nil, errors.New(rowLimitError)
+	}
+
+	columns, err := readHeader(records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var rooms []Room
+	roomIndex := map[string]int{}
+	var errs Errors
+
+	for _, row := range records[1:] {
+		line := row.line
+		if isBlank(row.cells) {
+			continue
+		}
+
+		r := rowReader{row: row.cells, line: line, columns: columns}
+		name := r.text(ColumnRoom)
+		label := r.text(ColumnWall)
+		wall := paint.WallInput{
+			Width:          r.number(ColumnWidth),
+			Height:         r.number(ColumnHeight),
+			DoorQuantity:   r.integer(ColumnDoors),
+			WindowQuantity: r.integer(ColumnWindows),
+		}
+		if len(r.errs) > 0 {
+			errs = append(errs, r.errs...)
+			continue
+		}
+
+		index, ok := roomIndex[name]
+		if !ok {
+			index = len(rooms)
+			roomIndex[name] = index
+			rooms = append(rooms, Room{Name: name})
+		}
+		room := &rooms[index]
+
+		if contains(room.Walls, label) {
+			errs = append(errs, &CellError{Row: line, Column: columns[ColumnWall] + 1, Field: ColumnWall, Err: errors.New(duplicateWallError)})
+			continue
+		}
+
+		candidate := room.Input
+		candidate.Walls = append(append([]paint.WallInput{}, room.Input.Walls...), wall)
+		_, err := calculate(ctx, candidate)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			errs = append(errs, calculationError(line, columns, err))
+			continue
+		}
+
+		room.Walls = append(room.Walls, label)
+		room.Input = candidate
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if len(rooms) == 0 {
+		return nil, errors.New(emptyFileError)
+	}
+
+	return rooms, nil
+}
+
+// calculationError points at the cell of the wall field that err points at,
+// such as /walls/2/door_quantity, or at the row when err is about the whole
+// wall or room.
+func calculationError(line int, columns map[string]int, err error) *CellError {
+	cellError := &CellError{Row: line, Err: err}
+
+	segments := strings.Split(paint.Pointer(err), "/")
+	if name, ok := fieldColumns[segments[len(segments)-1]]; ok && len(segments) == 4 {
+		cellError.Column = columns[name] + 1
+		cellError.Field = name
+	}
+	return cellError
+}
+
+func readHeader(header record) (map[string]int, error) {
+	columns := map[string]int{}
+	var errs Errors
+
+	for i, cell := range header.cells {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cell, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			errs = append(errs, &CellError{Row: header.line, Column: i + 1, Field: name, Err: errors.New(duplicateColumnError)})
+			continue
+		}
+		columns[name] = i
+	}
+
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			errs = append(errs, &CellError{Row: header.line, Field: name, Err: errors.New(missingColumnError)})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return columns, nil
+}
+
+// rowReader reads the cells of one data row, collecting an error for every
+// cell that is missing or malformed instead of stopping at the first one.
+type rowReader struct {
+	row     []string
+	line    int
+	columns map[string]int
+	errs    Errors
+}
+
+func (r *rowReader) cell(name string) string {
+	column := r.columns[name]
+	if column >= len(r.row) {
+		return ""
+	}
+	return strings.TrimSpace(r.row[column])
+}
+
+func (r *rowReader) fail(name string, message string) {
+	r.errs = append(r.errs, &CellError{Row: r.line, Column: r.columns[name] + 1, Field: name, Err: errors.New(message)})
+}
+
+func (r *rowReader) text(name string) string {
+	value := r.cell(name)
+	if value == "" {
+		r.fail(name, emptyValueError)
+	}
+	return value
+}
+
+func (r *rowReader) number(name string) float64 {
+	value := r.text(name)
+	if value == "" {
+		return 0
+	}
+
+	number, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil {
+		r.fail(name, invalidNumberError)
+	}
+	return number
+}
+
+// integer reads a quantity. Empty cells count as zero since surveyors often
+// leave the doors and windows columns blank.
+func (r *rowReader) integer(name string) int {
+	value := r.cell(name)
+	if value == "" {
+		return 0
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		r.fail(name, invalidIntegerError)
+	}
+	return number
+}
+
+func isBlank(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"digitalrepublic/pkg/paint"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	file := "Room;Wall;Width;Height;Doors;Windows\n" +
+		"sala;norte;5;2,5;1;1\n" +
+		"sala;sul;5;2,5;;\n" +
+		"\n" +
+		"quarto;leste;3.5;2.5;1;0\n"
+
+	got, err := ReadCSV(context.Background(), strings.NewReader(file), paint.NewCalculateRoomPaintInCans().Execute)
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+
+	want := []Room{
+		{Name: "sala", Walls: []string{"norte", "sul"}, Input: paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{
+			{Width: 5, Height: 2.5, DoorQuantity: 1, WindowQuantity: 1},
+			{Width: 5, Height: 2.5},
+		}}},
+		{Name: "quarto", Walls: []string{"leste"}, Input: paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{
+			{Width: 3.5, Height: 2.5, DoorQuantity: 1},
+		}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadCSV() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want []CellError
+	}{
+		{
+			name: "Should_MissingColumnError_When_HeaderIncomplete",
+			file: "room,wall,width,height,doors\nsala,1,5,5,0\n",
+			want: []CellError{{Row: 1, Field: ColumnWindows}},
+		},
+		{
+			name: "Should_PointAtCells_When_ValuesInvalid",
+			file: "room,wall,width,height,doors,windows\n" +
+				"sala,1,cinco,2.5,0,0\n" +
+				"sala,2,5,,1.5,0\n",
+			want: []CellError{
+				{Row: 2, Column: 3, Field: ColumnWidth},
+				{Row: 3, Column: 4, Field: ColumnHeight},
+				{Row: 3, Column: 5, Field: ColumnDoors},
+			},
+		},
+		{
+			name: "Should_PointAtRow_When_WallInvalid",
+			file: "room,wall,width,height,doors,windows\n" +
+				"sala,1,5,2.5,0,0\n" +
+				"sala,2,0.5,0.5,0,0\n" +
+				"sala,1,5,2.5,0,0\n",
+			want: []CellError{
+				{Row: 3},
+				{Row: 4, Column: 2, Field: ColumnWall},
+			},
+		},
+		{
+			name: "Should_PointAtCell_When_WallBreaksACalculationRule",
+			file: "room,wall,width,height,doors,windows\n" +
+				"sala,1,2,2.5,3,0\n" +
+				"sala,2,5,2,1,0\n" +
+				"sala,3,5,2.5,0,-1\n",
+			want: []CellError{
+				{Row: 2, Column: 5, Field: ColumnDoors},
+				{Row: 3, Column: 4, Field: ColumnHeight},
+				{Row: 4, Column: 6, Field: ColumnWindows},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadCSV(context.Background(), strings.NewReader(tt.file), paint.NewCalculateRoomPaintInCans().Execute)
+
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("ReadCSV() error = %v, want Errors", err)
+			}
+			if len(errs) != len(tt.want) {
+				t.Fatalf("ReadCSV() errors = %v, want %d errors", errs, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := errs[i]
+				if got.Row != want.Row || got.Column != want.Column || got.Field != want.Field {
+					t.Errorf("ReadCSV() error %d = %v (row %d, column %d, field %q), want row %d, column %d, field %q",
+						i, got, got.Row, got.Column, got.Field, want.Row, want.Column, want.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestCellError_Error(t *testing.T) {
+	err := &CellError{Row: 3, Column: 4, Field: ColumnHeight, Err: errors.New(invalidNumberError)}
+	if got, want := err.Error(), "linha 3, coluna D (height): valor numérico invalido"; got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
+
+func TestReadXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, _ := w.Create("xl/worksheets/sheet1.xml")
+	f.Write([]byte(`<worksheet><sheetData>
+		<row r="1">` + inlineCells("room", "wall", "width", "height", "doors", "windows") + `</row>
+		<row r="2">` + inlineCells("sala", "1") + `<c r="C2"><v>5</v></c><c r="D2"><v>2.5</v></c><c r="E2"><v>1</v></c></row>
+		</sheetData></worksheet>`))
+	w.Close()
+
+	got, err := ReadXLSX(context.Background(), bytes.NewReader(buf.Bytes()), int64(buf.Len()), paint.NewCalculateRoomPaintInCans().Execute)
+	if err != nil {
+		t.Fatalf("ReadXLSX() error = %v", err)
+	}
+
+	want := []Room{{Name: "sala", Walls: []string{"1"}, Input: paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{
+		{Width: 5, Height: 2.5, DoorQuantity: 1},
+	}}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadXLSX() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadXLSX_SparseRows(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, _ := w.Create("xl/worksheets/sheet1.xml")
+	f.Write([]byte(`<worksheet><sheetData>
+		<row r="1">` + inlineCells("room", "wall", "width", "height", "doors", "windows") + `</row>
+		<row r="5">` + inlineCells("sala", "1") + `<c r="C5"><v>cinco</v></c><c r="D5"><v>2.5</v></c></row>
+		</sheetData></worksheet>`))
+	w.Close()
+
+	_, err := ReadXLSX(context.Background(), bytes.NewReader(buf.Bytes()), int64(buf.Len()), paint.NewCalculateRoomPaintInCans().Execute)
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Row != 5 || errs[0].Column != 3 {
+		t.Errorf("ReadXLSX() error = %v, want row 5, column 3", err)
+	}
+}
+
+func TestReadCSV_RowLimit(t *testing.T) {
+	file := "room,wall,width,height,doors,windows\n" + strings.Repeat("sala,1,5,2.5,0,0\n", MaxRows+1)
+
+	_, err := ReadCSV(context.Background(), strings.NewReader(file), paint.NewCalculateRoomPaintInCans().Execute)
+	if err == nil || err.Error() != rowLimitError {
+		t.Errorf("ReadCSV() error = %v, want %v", err, rowLimitError)
+	}
+}
+
+func inlineCells(values ...string) string {
+	var b strings.Builder
+	for _, value := range values {
+		b.WriteString(`<c t="inlineStr"><is><t>` + value + `</t></is></c>`)
+	}
+	return b.String()
+}
@@ -0,0 +1,207 @@
+// Package jsonrpc answers JSON-RPC 2.0 requests, single or batched, with the
+// standard library only; net/rpc/jsonrpc speaks version 1.0.
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Version is the only protocol version accepted.
+const Version = "2.0"
+
+// Codes of the errors defined by the specification.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Error is the error member of a response.
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Method answers one call. params is the params member as sent, empty when
+// the request has none.
+type Method func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+var null = json.RawMessage("null")
+
+// Server dispatches requests to the methods registered on it. Register every
+// method before serving; Handle is then safe for concurrent use.
+type Server struct {
+	methods map[string]Method
+	toError func(ctx context.Context, err error) *Error
+}
+
+// NewServer returns a server that turns the errors returned by its methods
+// into response errors with toError, given the context of the call. An *Error
+// returned by a method is sent as it is, and without toError every other
+// error, panics included, is an internal error without its message.
+func NewServer(toError func(ctx context.Context, err error) *Error) *Server {
+	return &Server{methods: map[string]Method{}, toError: toError}
+}
+
+func (s *Server) Register(name string, method Method) {
+	s.methods[name] = method
+}
+
+// Methods returns the names of the registered methods, sorted.
+func (s *Server) Methods() []string {
+	names := make([]string, 0, len(s.methods))
+	for name := range s.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Handle answers the request or batch of requests in body. It returns nil
+// when there is nothing to answer, as for notifications.
+func (s *Server) Handle(ctx context.Context, body []byte) []byte {
+	body = bytes.TrimSpace(body)
+	if !json.Valid(body) {
+		return encode(errorResponse(null, CodeParseError, "Parse error"))
+	}
+
+	if body[0] != '[' {
+		answer := s.call(ctx, body)
+		if answer == nil {
+			return nil
+		}
+		return encode(*answer)
+	}
+
+	var batch []json.RawMessage
+	_ = json.Unmarshal(body, &batch)
+	if len(batch) == 0 {
+		return encode(errorResponse(null, CodeInvalidRequest, "Invalid Request"))
+	}
+
+	answers := make([]response, 0, len(batch))
+	for _, raw := range batch {
+		if answer := s.call(ctx, raw); answer != nil {
+			answers = append(answers, *answer)
+		}
+	}
+	if len(answers) == 0 {
+		return nil
+	}
+	return encode(answers)
+}
+
+// call answers one request, returning nil for notifications: requests
+// without an id, which get no response even when they fail.
+func (s *Server) call(ctx context.Context, raw json.RawMessage) *response {
+	var req request
+	err := json.Unmarshal(raw, &req)
+	if err != nil || req.JSONRPC != Version || req.Method == "" || !validID(req.ID) || !validParams(req.Params) {
+		id := null
+		if err == nil && validID(req.ID) && req.ID != nil {
+			id = req.ID
+		}
+		answer := errorResponse(id, CodeInvalidRequest, "Invalid Request")
+		return &answer
+	}
+
+	answer := s.invoke(ctx, req)
+	if req.ID == nil {
+		return nil
+	}
+	return &answer
+}
+
+func (s *Server) invoke(ctx context.Context, req request) (answer response) {
+	method, ok := s.methods[req.Method]
+	if !ok {
+		return errorResponse(req.ID, CodeMethodNotFound, "Method not found")
+	}
+
+	// A panic is answered like any other error, so its message is only
+	// logged by toError and never sent to the caller.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err := fmt.Errorf("panic in %s: %v", req.Method, recovered)
+			answer = response{JSONRPC: Version, Error: s.errorOf(ctx, err), ID: req.ID}
+		}
+	}()
+
+	result, err := method(ctx, req.Params)
+	if err != nil {
+		return response{JSONRPC: Version, Error: s.errorOf(ctx, err), ID: req.ID}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return response{JSONRPC: Version, Error: s.errorOf(ctx, err), ID: req.ID}
+	}
+	return response{JSONRPC: Version, Result: data, ID: req.ID}
+}
+
+func (s *Server) errorOf(ctx context.Context, err error) *Error {
+	if rpcErr, ok := err.(*Error); ok {
+		return rpcErr
+	}
+	if s.toError != nil {
+		return s.toError(ctx, err)
+	}
+	return &Error{Code: CodeInternalError, Message: "Internal error"}
+}
+
+// validID accepts the ids allowed by the specification: a string, a number,
+// null or none at all.
+func validID(id json.RawMessage) bool {
+	if id == nil {
+		return true
+	}
+	var value interface{}
+	_ = json.Unmarshal(id, &value)
+	switch value.(type) {
+	case nil, string, float64:
+		return true
+	}
+	return false
+}
+
+// validParams accepts params by name or by position, or none.
+func validParams(params json.RawMessage) bool {
+	return params == nil || params[0] == '{' || params[0] == '['
+}
+
+func errorResponse(id json.RawMessage, code int, message string) response {
+	return response{JSONRPC: Version, Error: &Error{Code: code, Message: message}, ID: id}
+}
+
+func encode(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return encode(errorResponse(null, CodeInternalError, "Internal error"))
+	}
+	return data
+}
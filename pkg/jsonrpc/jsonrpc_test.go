@@ -0,0 +1,159 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func newTestServer() *Server {
+	server := NewServer(func(ctx context.Context, err error) *Error {
+		return &Error{Code: -32000, Message: "Server error", Data: err.Error()}
+	})
+	server.Register("subtract", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var operands []int
+		if err := json.Unmarshal(params, &operands); err != nil || len(operands) != 2 {
+			return nil, &Error{Code: CodeInvalidParams, Message: "Invalid params"}
+		}
+		return operands[0] - operands[1], nil
+	})
+	server.Register("nothing", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+	server.Register("fail", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		return nil, errors.New("sem tinta")
+	})
+	server.Register("panic", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		panic("boom")
+	})
+	return server
+}
+
+func TestServer_Handle(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "Should_ReturnResult_When_CallSucceeds",
+			body: `{"jsonrpc": "2.0", "method": "subtract", "params": [42, 23], "id": 1}`,
+			want: `{"jsonrpc":"2.0","result":19,"id":1}`,
+		},
+		{
+			name: "Should_ReturnNullResult_When_MethodReturnsNothing",
+			body: `{"jsonrpc": "2.0", "method": "nothing", "id": "a"}`,
+			want: `{"jsonrpc":"2.0","result":null,"id":"a"}`,
+		},
+		{
+			name: "Should_ReturnNothing_When_RequestIsNotification",
+			body: `{"jsonrpc": "2.0", "method": "fail"}`,
+			want: ``,
+		},
+		{
+			name: "Should_ReturnParseError_When_BodyIsNotJSON",
+			body: `{"jsonrpc": "2.0", "method": "foobar, "params": "bar", "baz]`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32700,"message":"Parse error"},"id":null}`,
+		},
+		{
+			name: "Should_ReturnInvalidRequest_When_VersionIsMissing",
+			body: `{"method": "subtract", "params": [1, 2], "id": 3}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":3}`,
+		},
+		{
+			name: "Should_ReturnInvalidRequest_When_MethodIsNotAString",
+			body: `{"jsonrpc": "2.0", "method": 1, "params": "bar"}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}`,
+		},
+		{
+			name: "Should_ReturnInvalidRequest_When_ParamsAreNotStructured",
+			body: `{"jsonrpc": "2.0", "method": "subtract", "params": 5, "id": 4}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":4}`,
+		},
+		{
+			name: "Should_ReturnInvalidRequest_When_IDIsAnObject",
+			body: `{"jsonrpc": "2.0", "method": "nothing", "id": {}}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}`,
+		},
+		{
+			name: "Should_ReturnMethodNotFound_When_MethodIsNotRegistered",
+			body: `{"jsonrpc": "2.0", "method": "foobar", "id": "1"}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":"1"}`,
+		},
+		{
+			name: "Should_SendErrorAsIs_When_MethodReturnsError",
+			body: `{"jsonrpc": "2.0", "method": "subtract", "params": [1], "id": 5}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params"},"id":5}`,
+		},
+		{
+			name: "Should_ConvertError_When_MethodReturnsOtherError",
+			body: `{"jsonrpc": "2.0", "method": "fail", "id": 6}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32000,"message":"Server error","data":"sem tinta"},"id":6}`,
+		},
+		{
+			name: "Should_ConvertPanic_When_MethodPanics",
+			body: `{"jsonrpc": "2.0", "method": "panic", "id": 7}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32000,"message":"Server error","data":"panic in panic: boom"},"id":7}`,
+		},
+		{
+			name: "Should_ReturnInvalidRequest_When_BatchIsEmpty",
+			body: `[]`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}`,
+		},
+		{
+			name: "Should_AnswerEachRequest_When_BatchIsInvalid",
+			body: `[1, 2]`,
+			want: `[{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null},{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}]`,
+		},
+		{
+			name: "Should_SkipNotifications_When_BatchMixesThem",
+			body: `[{"jsonrpc": "2.0", "method": "subtract", "params": [5, 3], "id": 1}, {"jsonrpc": "2.0", "method": "fail"}, {"jsonrpc": "2.0", "method": "foo", "id": 2}]`,
+			want: `[{"jsonrpc":"2.0","result":2,"id":1},{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":2}]`,
+		},
+		{
+			name: "Should_ReturnNothing_When_BatchHasOnlyNotifications",
+			body: `[{"jsonrpc": "2.0", "method": "nothing"}, {"jsonrpc": "2.0", "method": "fail"}]`,
+			want: ``,
+		},
+	}
+	server := newTestServer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := server.Handle(context.Background(), []byte(tt.body))
+			if string(got) != tt.want {
+				t.Errorf("Handle() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_Handle_WithoutToError(t *testing.T) {
+	server := NewServer(nil)
+	server.Register("fail", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		return nil, errors.New("open /var/lib/paint: permission denied")
+	})
+	server.Register("panic", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		panic("boom")
+	})
+
+	for _, method := range []string{"fail", "panic"} {
+		got := server.Handle(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "`+method+`", "id": 1}`))
+		if want := `{"jsonrpc":"2.0","error":{"code":-32603,"message":"Internal error"},"id":1}`; string(got) != want {
+			t.Errorf("Handle(%s) = %s, want %s", method, got, want)
+		}
+	}
+}
+
+func TestServer_Methods(t *testing.T) {
+	got := newTestServer().Methods()
+	want := []string{"fail", "nothing", "panic", "subtract"}
+	if len(got) != len(want) {
+		t.Fatalf("Methods() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Methods() = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Package live keeps the room of a live editing session, changed one edit at
+// a time by clients that recalculate on every keystroke.
+package live
+
+import (
+	"digitalrepublic/pkg/decoder"
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/paint"
+	"encoding/json"
+)
+
+const (
+	unknownOpError      = "operação desconhecida"
+	wallNotFoundError   = "parede não encontrada"
+	unknownOpeningError = "abertura desconhecida, use door ou window"
+	noOpeningError      = "a parede não tem essa abertura para remover"
+	wallLimitError      = "o ambiente atingiu o limite de paredes da sessão"
+)
+
+// Codes of the rejected edits.
+const (
+	CodeUnknownOp      = "edit_unknown_op"
+	CodeWallNotFound   = "edit_wall_not_found"
+	CodeUnknownOpening = "edit_unknown_opening"
+	CodeNoOpening      = "edit_no_opening"
+	CodeWallLimit      = "edit_wall_limit"
+)
+
+// Operations of an edit.
+const (
+	OpAddWall       = "add_wall"
+	OpUpdateWall    = "update_wall"
+	OpRemoveWall    = "remove_wall"
+	OpAddOpening    = "add_opening"
+	OpRemoveOpening = "remove_opening"
+	OpReplaceRoom   = "replace_room"
+)
+
+// Openings that add_opening and remove_opening change.
+const (
+	OpeningDoor   = "door"
+	OpeningWindow = "window"
+)
+
+// MaxWalls bounds the walls a session holds. The room itself is limited to
+// fewer walls by the domain, which reports it as a validation error instead.
+const MaxWalls = 32
+
+// Edit is one change to the room. Index is the wall changed, Wall the fields
+// of the wall to add or update and Room the whole room to replace.
+type Edit struct {
+	Op      string          `json:"op"`
+	Index   int             `json:"index"`
+	Opening string          `json:"opening"`
+	Wall    json.RawMessage `json:"wall"`
+	Room    json.RawMessage `json:"room"`
+}
+
+// Session holds the room being edited. It is not safe for concurrent use;
+// each connection has its own.
+type Session struct {
+	room paint.CalculateRoomPaintInCansInput
+}
+
+func NewSession() *Session {
+	return &Session{room: paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{}}}
+}
+
+// Room returns a copy of the room as edited so far.
+func (s *Session) Room() paint.CalculateRoomPaintInCansInput {
+	walls := make([]paint.WallInput, len(s.room.Walls))
+	for i, wall := range s.room.Walls {
+		wall.Bands = append([]paint.BandInput(nil), wall.Bands...)
+		walls[i] = wall
+	}
+	return paint.CalculateRoomPaintInCansInput{Walls: walls}
+}
+
+// Apply changes the room with edit. A rejected edit leaves the room as it was
+// and returns an error pointing into the edit: a *paint.FieldError with a
+// validation code, or decoder.Errors when Wall or Room do not decode.
+func (s *Session) Apply(edit Edit) error {
+	switch edit.Op {
+	case OpAddWall:
+		if len(s.room.Walls) >= MaxWalls {
+			return editError(CodeWallLimit, wallLimitError, "/op")
+		}
+		var wall paint.WallInput
+		err := patch(edit.Wall, &wall, "/wall")
+		if err != nil {
+			return err
+		}
+		s.room.Walls = append(s.room.Walls, wall)
+
+	case OpUpdateWall:
+		wall, err := s.wall(edit.Index)
+		if err != nil {
+			return err
+		}
+		updated := *wall
+		updated.Bands = append([]paint.BandInput(nil), wall.Bands...)
+		err = patch(edit.Wall, &updated, "/wall")
+		if err != nil {
+			return err
+		}
+		*wall = updated
+
+	case OpRemoveWall:
+		if _, err := s.wall(edit.Index); err != nil {
+			return err
+		}
+		s.room.Walls = append(s.room.Walls[:edit.Index], s.room.Walls[edit.Index+1:]...)
+
+	case OpAddOpening, OpRemoveOpening:
+		wall, err := s.wall(edit.Index)
+		if err != nil {
+			return err
+		}
+		quantity, err := opening(wall, edit.Opening)
+		if err != nil {
+			return err
+		}
+		if edit.Op == OpAddOpening {
+			*quantity++
+		} else if *quantity > 0 {
+			*quantity--
+		} else {
+			return editError(CodeNoOpening, noOpeningError, "/opening")
+		}
+
+	case OpReplaceRoom:
+		var room paint.CalculateRoomPaintInCansInput
+		err := decoder.Strict(edit.Room, &room)
+		if err != nil {
+			return prefix(err, "/room")
+		}
+		if len(room.Walls) > MaxWalls {
+			return editError(CodeWallLimit, wallLimitError, "/room/walls")
+		}
+		if room.Walls == nil {
+			room.Walls = []paint.WallInput{}
+		}
+		s.room = room
+
+	default:
+		return editError(CodeUnknownOp, unknownOpError, "/op")
+	}
+
+	return nil
+}
+
+func (s *Session) wall(index int) (*paint.WallInput, error) {
+	if index < 0 || index >= len(s.room.Walls) {
+		return nil, editError(CodeWallNotFound, wallNotFoundError, "/index")
+	}
+	return &s.room.Walls[index], nil
+}
+
+func opening(wall *paint.WallInput, kind string) (*int, error) {
+	switch kind {
+	case OpeningDoor:
+		return &wall.DoorQuantity, nil
+	case OpeningWindow:
+		return &wall.WindowQuantity, nil
+	}
+	return nil, editError(CodeUnknownOpening, unknownOpeningError, "/opening")
+}
+
+// patch decodes the fields of data over target; an absent data changes
+// nothing.
+func patch(data json.RawMessage, target *paint.WallInput, pointer string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return prefix(decoder.Patch(data, target), pointer)
+}
+
+// prefix moves the pointers of decoding errors under pointer, so they point
+// into the edit rather than into the decoded field.
+func prefix(err error, pointer string) error {
+	fields, ok := err.(decoder.Errors)
+	if !ok {
+		return err
+	}
+	for _, field := range fields {
+		field.Pointer = pointer + field.Pointer
+	}
+	return fields
+}
+
+func editError(code, message, pointer string) error {
+	return &paint.FieldError{Pointer: pointer, Err: entities.NewValidationError(code, message)}
+}
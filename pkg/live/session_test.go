@@ -0,0 +1,156 @@
+package live
+
+import (
+	"digitalrepublic/pkg/decoder"
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/paint"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSession_Apply(t *testing.T) {
+	start := []Edit{
+		{Op: OpAddWall, Wall: json.RawMessage(`{"width": 5, "height": 2.5}`)},
+		{Op: OpAddWall, Wall: json.RawMessage(`{"width": 3, "height": 2.5, "door_quantity": 1}`)},
+	}
+
+	tests := []struct {
+		name        string
+		edit        Edit
+		want        []paint.WallInput
+		wantCode    string
+		wantPointer string
+	}{
+		{
+			name: "Should_AddWall_When_OpIsAddWall",
+			edit: Edit{Op: OpAddWall},
+			want: []paint.WallInput{{Width: 5, Height: 2.5}, {Width: 3, Height: 2.5, DoorQuantity: 1}, {}},
+		},
+		{
+			name: "Should_ChangeOnlyTheSentFields_When_OpIsUpdateWall",
+			edit: Edit{Op: OpUpdateWall, Index: 1, Wall: json.RawMessage(`{"width": 4, "paint_doors": true}`)},
+			want: []paint.WallInput{{Width: 5, Height: 2.5}, {Width: 4, Height: 2.5, DoorQuantity: 1, PaintDoors: true}},
+		},
+		{
+			name: "Should_RemoveWall_When_OpIsRemoveWall",
+			edit: Edit{Op: OpRemoveWall, Index: 0},
+			want: []paint.WallInput{{Width: 3, Height: 2.5, DoorQuantity: 1}},
+		},
+		{
+			name: "Should_AddWindow_When_OpIsAddOpening",
+			edit: Edit{Op: OpAddOpening, Index: 0, Opening: OpeningWindow},
+			want: []paint.WallInput{{Width: 5, Height: 2.5, WindowQuantity: 1}, {Width: 3, Height: 2.5, DoorQuantity: 1}},
+		},
+		{
+			name: "Should_RemoveDoor_When_OpIsRemoveOpening",
+			edit: Edit{Op: OpRemoveOpening, Index: 1, Opening: OpeningDoor},
+			want: []paint.WallInput{{Width: 5, Height: 2.5}, {Width: 3, Height: 2.5}},
+		},
+		{
+			name: "Should_ReplaceWalls_When_OpIsReplaceRoom",
+			edit: Edit{Op: OpReplaceRoom, Room: json.RawMessage(`{"walls": [{"width": 2, "height": 2}]}`)},
+			want: []paint.WallInput{{Width: 2, Height: 2}},
+		},
+		{
+			name:        "Should_RejectEdit_When_OpIsUnknown",
+			edit:        Edit{Op: "rotate_wall"},
+			wantCode:    CodeUnknownOp,
+			wantPointer: "/op",
+		},
+		{
+			name:        "Should_RejectEdit_When_WallDoesNotExist",
+			edit:        Edit{Op: OpUpdateWall, Index: 2, Wall: json.RawMessage(`{"width": 4}`)},
+			wantCode:    CodeWallNotFound,
+			wantPointer: "/index",
+		},
+		{
+			name:        "Should_RejectEdit_When_OpeningIsUnknown",
+			edit:        Edit{Op: OpAddOpening, Index: 0, Opening: "skylight"},
+			wantCode:    CodeUnknownOpening,
+			wantPointer: "/opening",
+		},
+		{
+			name:        "Should_RejectEdit_When_WallHasNoOpeningToRemove",
+			edit:        Edit{Op: OpRemoveOpening, Index: 0, Opening: OpeningDoor},
+			wantCode:    CodeNoOpening,
+			wantPointer: "/opening",
+		},
+		{
+			name:        "Should_RejectEdit_When_WallFieldIsUnknown",
+			edit:        Edit{Op: OpUpdateWall, Index: 0, Wall: json.RawMessage(`{"width": 4, "depth": 1}`)},
+			wantCode:    decoder.CodeUnknownField,
+			wantPointer: "/wall/depth",
+		},
+		{
+			name:        "Should_RejectEdit_When_ReplacedRoomMissesRequiredField",
+			edit:        Edit{Op: OpReplaceRoom, Room: json.RawMessage(`{"walls": [{"width": 2}]}`)},
+			wantCode:    decoder.CodeRequired,
+			wantPointer: "/room/walls/0/height",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewSession()
+			for _, edit := range start {
+				if err := session.Apply(edit); err != nil {
+					t.Fatalf("Apply() error = %v", err)
+				}
+			}
+			before := session.Room()
+
+			err := session.Apply(tt.edit)
+
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("Apply() error = %v", err)
+				}
+				if got := session.Room().Walls; !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Room().Walls = %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+
+			code, pointer := entities.ValidationCode(err), paint.Pointer(err)
+			var fields decoder.Errors
+			if asFields, ok := err.(decoder.Errors); ok {
+				fields = asFields
+				code, pointer = fields[0].Code, fields[0].Pointer
+			}
+			if code != tt.wantCode || pointer != tt.wantPointer {
+				t.Errorf("Apply() error = %v (%s at %s), want %s at %s", err, code, pointer, tt.wantCode, tt.wantPointer)
+			}
+			if got := session.Room(); !reflect.DeepEqual(got, before) {
+				t.Errorf("Room() = %+v after a rejected edit, want %+v", got, before)
+			}
+		})
+	}
+}
+
+func TestSession_Apply_LimitsWalls(t *testing.T) {
+	session := NewSession()
+	for i := 0; i < MaxWalls; i++ {
+		if err := session.Apply(Edit{Op: OpAddWall}); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	}
+
+	err := session.Apply(Edit{Op: OpAddWall})
+	if entities.ValidationCode(err) != CodeWallLimit {
+		t.Errorf("Apply() error = %v, want %s", err, CodeWallLimit)
+	}
+}
+
+func TestSession_Room_ReturnsCopy(t *testing.T) {
+	session := NewSession()
+	_ = session.Apply(Edit{Op: OpAddWall, Wall: json.RawMessage(`{"width": 5, "height": 2.5, "bands": [{"from": 0, "to": 1, "color": "azul"}]}`)})
+
+	room := session.Room()
+	room.Walls[0].Width = 1
+	room.Walls[0].Bands[0].Color = "verde"
+
+	got := session.Room().Walls[0]
+	if got.Width != 5 || got.Bands[0].Color != "azul" {
+		t.Errorf("Room() shares state with the session: %+v", got)
+	}
+}
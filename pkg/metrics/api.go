@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"context"
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/paint"
+	"strconv"
+)
+
+// Paint kinds used as the paint label. Band colors are not used as labels
+// since they are free text and would create a series per color.
+const (
+	paintWall   = "wall"
+	paintBand   = "band"
+	paintEnamel = "enamel"
+)
+
+// Metrics are the metrics exposed by the API.
+type Metrics struct {
+	Registry           *Registry
+	Requests           *Counter
+	Latency            *Histogram
+	ValidationFailures *Counter
+	Calculations       *Counter
+	Liters             *Counter
+	Cans               *Counter
+}
+
+func New() *Metrics {
+	r := NewRegistry()
+	return &Metrics{
+		Registry: r,
+		Requests: r.Counter("http_requests_total",
+			"HTTP requests served, by route, method and status.", "route", "method", "status"),
+		Latency: r.Histogram("http_request_duration_seconds",
+			"Time to serve HTTP requests, by route, method and status.", DefaultBuckets, "route", "method", "status"),
+		ValidationFailures: r.Counter("paint_validation_failures_total",
+			"Calculations rejected by validation, by error code.", "code"),
+		Calculations: r.Counter("paint_calculations_total",
+			"Rooms calculated successfully."),
+		Liters: r.Counter("paint_liters_estimated_total",
+			"Liters of paint estimated for walls and bands, enamel excluded."),
+		Cans: r.Counter("paint_cans_recommended_total",
+			"Cans recommended, by paint kind and can size in liters.", "paint", "size"),
+	}
+}
+
+// Instrument wraps the interactor so that every calculation it runs is
+// recorded.
+func (m *Metrics) Instrument(interactor paint.CalculateRoomPaintInCans) paint.CalculateRoomPaintInCans {
+	return &instrumented{next: interactor, metrics: m}
+}
+
+type instrumented struct {
+	next    paint.CalculateRoomPaintInCans
+	metrics *Metrics
+}
+
+type unrecordedKey struct{}
+
+// Unrecorded returns a copy of ctx whose calculations are not recorded, for
+// previews such as the rooms of a live session, recalculated while they are
+// still being typed.
+func Unrecorded(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unrecordedKey{}, true)
+}
+
+// Execute records the outcome of the calculation. Calculations cut short by
+// ctx are not failures of the room and are left out.
+func (i *instrumented) Execute(ctx context.Context, input paint.CalculateRoomPaintInCansInput) (*paint.CalculateRoomPaintInCansOutput, error) {
+	if ctx.Value(unrecordedKey{}) != nil {
+		return i.next.Execute(ctx, input)
+	}
+
+	result, err := i.next.Execute(ctx, input)
+	if err != nil {
+		if ctx.Err() == nil {
+			i.metrics.ObserveFailure(err)
+		}
+		return nil, err
+	}
+
+	i.metrics.ObserveResult(*result)
+	return result, nil
+}
+
+// ObserveFailure counts a validation failure. Errors without a code are
+// counted as unknown.
+func (m *Metrics) ObserveFailure(err error) {
+	code := entities.ValidationCode(err)
+	if code == "" {
+		code = "unknown"
+	}
+	m.ValidationFailures.Inc(code)
+}
+
+func (m *Metrics) ObserveResult(result paint.CalculateRoomPaintInCansOutput) {
+	m.Calculations.Inc()
+	m.Liters.Add(result.Liters)
+
+	m.addCans(paintWall, result.ExtraLargeCan, result.LargeCan, result.MediumCan, result.SmallCan)
+	for _, band := range result.Bands {
+		m.addCans(paintBand, band.ExtraLargeCan, band.LargeCan, band.MediumCan, band.SmallCan)
+	}
+	if result.Enamel != nil {
+		m.addEnamelCans(result.Enamel.LargeCan, result.Enamel.MediumCan, result.Enamel.SmallCan)
+	}
+}
+
+func (m *Metrics) addCans(kind string, huge, big, medium, small int64) {
+	m.addCan(kind, paint.ExtraLargeCan, huge)
+	m.addCan(kind, paint.LargeCan, big)
+	m.addCan(kind, paint.MediumCan, medium)
+	m.addCan(kind, paint.SmallCan, small)
+}
+
+func (m *Metrics) addEnamelCans(big, medium, small int64) {
+	m.addCan(paintEnamel, paint.EnamelLargeCan, big)
+	m.addCan(paintEnamel, paint.EnamelMediumCan, medium)
+	m.addCan(paintEnamel, paint.EnamelSmallCan, small)
+}
+
+func (m *Metrics) addCan(kind string, size entities.Can, quantity int64) {
+	if quantity > 0 {
+		m.Cans.Add(float64(quantity), kind, strconv.FormatFloat(size.Liters(), 'f', -1, 64))
+	}
+}
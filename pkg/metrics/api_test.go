@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"digitalrepublic/pkg/paint"
+	"strings"
+	"testing"
+)
+
+func TestMetrics_Instrument(t *testing.T) {
+	m := New()
+	interactor := m.Instrument(paint.NewCalculateRoomPaintInCans())
+
+	valid := paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{
+		{Width: 4, Height: 2.5, TrimLength: 4, Bands: []paint.BandInput{{From: 0, To: 1, Color: "azul"}}},
+	}}
+	if _, err := interactor.Execute(context.Background(), valid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := interactor.Execute(context.Background(), paint.CalculateRoomPaintInCansInput{}); err == nil {
+		t.Fatal("Execute() error = nil, want wall_zero")
+	}
+
+	var out strings.Builder
+	if err := m.Registry.WriteText(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`paint_validation_failures_total{code="wall_zero"} 1`,
+		`paint_calculations_total 1`,
+		`paint_liters_estimated_total 2`,
+		`paint_cans_recommended_total{paint="wall",size="0.5"} 3`,
+		`paint_cans_recommended_total{paint="band",size="0.5"} 2`,
+		`paint_cans_recommended_total{paint="enamel",size="0.225"} 1`,
+	} {
+		if !strings.Contains(out.String(), want+"\n") {
+			t.Errorf("WriteText() is missing %q in\n%s", want, out.String())
+		}
+	}
+}
+
+func TestMetrics_Instrument_Unrecorded(t *testing.T) {
+	m := New()
+	interactor := m.Instrument(paint.NewCalculateRoomPaintInCans())
+	ctx := Unrecorded(context.Background())
+
+	valid := paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{{Width: 4, Height: 2.5}}}
+	if _, err := interactor.Execute(ctx, valid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := interactor.Execute(ctx, paint.CalculateRoomPaintInCansInput{}); err == nil {
+		t.Fatal("Execute() error = nil, want wall_zero")
+	}
+
+	var out strings.Builder
+	if err := m.Registry.WriteText(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, unwanted := range []string{`paint_validation_failures_total{`, `paint_calculations_total 1`} {
+		if strings.Contains(out.String(), unwanted) {
+			t.Errorf("WriteText() has %q, want unrecorded calculations left out\n%s", unwanted, out.String())
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the media type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds, in seconds, used for request latency.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds metrics and writes them in the Prometheus text format, in the
+// order they were registered.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+type metric interface {
+	write(w *bufio.Writer)
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// WriteText writes every metric in the Prometheus text exposition format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	metrics := append([]metric(nil), r.metrics...)
+	r.mu.Unlock()
+
+	out := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(out)
+	}
+	return out.Flush()
+}
+
+// family is what counters and histograms share: a name, help text, label
+// names and one series per combination of label values.
+type family struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string][]string
+}
+
+func newFamily(name, help string, labels []string) family {
+	return family{name: name, help: help, labels: labels, series: map[string][]string{}}
+}
+
+// key identifies a series by its label values. It must be called with mu held.
+func (f *family) key(values []string) string {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+
+	key := strings.Join(values, "\xff")
+	if _, ok := f.series[key]; !ok {
+		f.series[key] = append([]string(nil), values...)
+	}
+	return key
+}
+
+// sortedKeys must be called with mu held.
+func (f *family) sortedKeys() []string {
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *family) header(w *bufio.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, kind)
+}
+
+// labelPairs formats the labels of a series, with extra pairs appended, as
+// {a="1",b="2"}.
+func (f *family) labelPairs(values []string, extra ...string) string {
+	pairs := make([]string, 0, len(values)+len(extra)/2)
+	for i, value := range values {
+		pairs = append(pairs, f.labels[i]+`="`+escapeLabel(value)+`"`)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabel(extra[i+1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter is a value that only goes up, such as a number of requests.
+type Counter struct {
+	family
+	values map[string]float64
+}
+
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	c := &Counter{family: newFamily(name, help, labels), values: map[string]float64{}}
+	r.register(c)
+	return c
+}
+
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increases the counter; negative values are ignored.
+func (c *Counter) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[c.key(labelValues)] += value
+}
+
+func (c *Counter) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.header(w, "counter")
+	for _, key := range c.sortedKeys() {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(c.series[key]), formatValue(c.values[key]))
+	}
+}
+
+// Histogram counts observations, such as request durations, in buckets.
+type Histogram struct {
+	family
+	buckets []float64
+	values  map[string]*histogramValue
+}
+
+type histogramValue struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	h := &Histogram{family: newFamily(name, help, labels), buckets: buckets, values: map[string]*histogramValue{}}
+	r.register(h)
+	return h
+}
+
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := h.key(labelValues)
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogramValue{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = v
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			v.counts[i]++
+		}
+	}
+	v.count++
+	v.sum += value
+}
+
+func (h *Histogram) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.header(w, "histogram")
+	for _, key := range h.sortedKeys() {
+		values, v := h.series[key], h.values[key]
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(values, "le", formatValue(bound)), v.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(values, "le", "+Inf"), v.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(values), formatValue(v.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(values), v.count)
+	}
+}
+
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	r := NewRegistry()
+	requests := r.Counter("http_requests_total", "Requests served.", "route", "status")
+	latency := r.Histogram("http_request_duration_seconds", "Request latency.", []float64{0.5, 0.1}, "route")
+
+	requests.Inc("/b", "200")
+	requests.Inc("/a", "400")
+	requests.Add(2, "/b", "200")
+	requests.Add(-1, "/b", "200")
+	requests.Inc(`/"x"`, "200")
+	latency.Observe(0.05, "/a")
+	latency.Observe(0.3, "/a")
+	latency.Observe(1, "/a")
+
+	want := `# HELP http_requests_total Requests served.
+# TYPE http_requests_total counter
+http_requests_total{route="/\"x\"",status="200"} 1
+http_requests_total{route="/a",status="400"} 1
+http_requests_total{route="/b",status="200"} 3
+# HELP http_request_duration_seconds Request latency.
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{route="/a",le="0.1"} 1
+http_request_duration_seconds_bucket{route="/a",le="0.5"} 2
+http_request_duration_seconds_bucket{route="/a",le="+Inf"} 3
+http_request_duration_seconds_sum{route="/a"} 1.35
+http_request_duration_seconds_count{route="/a"} 3
+`
+
+	var out strings.Builder
+	if err := r.WriteText(&out); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != want {
+		t.Errorf("WriteText() = \n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestCounter_Inc(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Inc() did not panic on missing label values")
+		}
+	}()
+
+	NewRegistry().Counter("c", "", "route").Inc()
+}
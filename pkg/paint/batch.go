@@ -0,0 +1,126 @@
+package paint
+
+import (
+	"context"
+	"digitalrepublic/pkg/entities"
+	"sync"
+)
+
+const (
+	emptyBatchError       = "o lote precisa de pelo menos um ambiente"
+	batchLimitError       = "o lote ultrapassa o limite de ambientes"
+	missingBatchIDError   = "todo ambiente do lote precisa de um id"
+	duplicateBatchIDError = "id repetido no lote"
+)
+
+const (
+	CodeBatchEmpty       = "batch_empty"
+	CodeBatchLimit       = "batch_limit"
+	CodeBatchIDMissing   = "batch_id_missing"
+	CodeBatchIDDuplicate = "batch_id_duplicate"
+)
+
+// MaxBatchSize is the largest number of rooms accepted in one batch.
+const MaxBatchSize = 1000
+
+// BatchItem is one room of a batch. The ID is chosen by the client and is
+// echoed back with the result so the items can be matched in any order.
+type BatchItem struct {
+	ID string `json:"id"`
+	CalculateRoomPaintInCansInput
+}
+
+// BatchResult holds either the result or the error of one item.
+type BatchResult struct {
+	ID     string                          `json:"id"`
+	Result *CalculateRoomPaintInCansOutput `json:"result,omitempty"`
+	Error  string                          `json:"error,omitempty"`
+}
+
+// ValidateBatch checks the batch itself; the rooms are only validated when
+// they are calculated, so one invalid room does not reject the others.
+func ValidateBatch(items []BatchItem) error {
+	if len(items) == 0 {
+		return atField(entities.NewValidationError(CodeBatchEmpty, emptyBatchError), "/rooms")
+	}
+	if len(items) > MaxBatchSize {
+		return atField(entities.NewValidationError(CodeBatchLimit, batchLimitError), "/rooms")
+	}
+
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		if item.ID == "" {
+			return atField(entities.NewValidationError(CodeBatchIDMissing, missingBatchIDError), "/rooms/%d/id", i)
+		}
+		if seen[item.ID] {
+			return atField(entities.NewValidationError(CodeBatchIDDuplicate, duplicateBatchIDError+": "+item.ID), "/rooms/%d/id", i)
+		}
+		seen[item.ID] = true
+	}
+
+	return nil
+}
+
+// ExecuteBatch calculates the items with at most workers running at the same
+// time and calls emit with each result as soon as it is ready, so results do
+// not come in the order of the items. emit is never called concurrently. Once
+// ctx is done the items left are not calculated nor emitted, and the error of
+// ctx is returned.
+func ExecuteBatch(ctx context.Context, interactor CalculateRoomPaintInCans, items []BatchItem, workers int, emit func(BatchResult)) error {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	jobs := make(chan BatchItem)
+	results := make(chan BatchResult)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				result, ok := executeBatchItem(ctx, interactor, item)
+				if ok {
+					results <- result
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, item := range items {
+			select {
+			case jobs <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		emit(result)
+	}
+	return ctx.Err()
+}
+
+// executeBatchItem calculates the item, reporting false when ctx ended first.
+func executeBatchItem(ctx context.Context, interactor CalculateRoomPaintInCans, item BatchItem) (BatchResult, bool) {
+	result, err := interactor.Execute(ctx, item.CalculateRoomPaintInCansInput)
+	if ctx.Err() != nil {
+		return BatchResult{}, false
+	}
+	if err != nil {
+		return BatchResult{ID: item.ID, Error: err.Error()}, true
+	}
+
+	return BatchResult{ID: item.ID, Result: result}, true
+}
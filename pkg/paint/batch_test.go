@@ -0,0 +1,117 @@
+package paint
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestValidateBatch(t *testing.T) {
+	valid := CalculateRoomPaintInCansInput{Walls: []WallInput{{Width: 2, Height: 2}}}
+	tooMany := make([]BatchItem, MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = BatchItem{ID: strconv.Itoa(i), CalculateRoomPaintInCansInput: valid}
+	}
+
+	tests := []struct {
+		name    string
+		items   []BatchItem
+		wantErr bool
+	}{
+		{
+			name:    "Should_ReturnNil_When_IDsAreUnique",
+			items:   []BatchItem{{ID: "sala"}, {ID: "quarto"}},
+			wantErr: false,
+		},
+		{
+			name:    "Should_ReturnError_When_BatchIsEmpty",
+			items:   nil,
+			wantErr: true,
+		},
+		{
+			name:    "Should_ReturnError_When_IDIsMissing",
+			items:   []BatchItem{{ID: "sala"}, {}},
+			wantErr: true,
+		},
+		{
+			name:    "Should_ReturnError_When_IDIsRepeated",
+			items:   []BatchItem{{ID: "sala"}, {ID: "sala"}},
+			wantErr: true,
+		},
+		{
+			name:    "Should_ReturnError_When_BatchExceedsLimit",
+			items:   tooMany,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateBatch(tt.items); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecuteBatch(t *testing.T) {
+	items := []BatchItem{
+		{ID: "sala", CalculateRoomPaintInCansInput: CalculateRoomPaintInCansInput{Walls: []WallInput{{Width: 5, Height: 3}}}},
+		{ID: "invalido", CalculateRoomPaintInCansInput: CalculateRoomPaintInCansInput{Walls: []WallInput{{Width: -1, Height: 3}}}},
+	}
+	for i := 0; i < 50; i++ {
+		items = append(items, BatchItem{
+			ID:                            "quarto-" + strconv.Itoa(i),
+			CalculateRoomPaintInCansInput: CalculateRoomPaintInCansInput{Walls: []WallInput{{Width: 4, Height: 2.5}}},
+		})
+	}
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		t.Run("Should_ReturnEveryItem_When_Workers"+strconv.Itoa(workers), func(t *testing.T) {
+			results := map[string]BatchResult{}
+			ExecuteBatch(context.Background(), NewCalculateRoomPaintInCans(), items, workers, func(result BatchResult) {
+				results[result.ID] = result
+			})
+
+			if len(results) != len(items) {
+				t.Fatalf("ExecuteBatch() returned %d results, want %d", len(results), len(items))
+			}
+			if results["sala"].Result == nil || results["sala"].Error != "" {
+				t.Errorf("ExecuteBatch() sala = %+v, want a result", results["sala"])
+			}
+			if results["invalido"].Result != nil || results["invalido"].Error == "" {
+				t.Errorf("ExecuteBatch() invalido = %+v, want an error", results["invalido"])
+			}
+			if got := results["quarto-7"].Result; got == nil || got.Area != 10 {
+				t.Errorf("ExecuteBatch() quarto-7 = %+v, want area 10", got)
+			}
+		})
+	}
+}
+
+func TestExecuteBatch_Canceled(t *testing.T) {
+	items := make([]BatchItem, 100)
+	for i := range items {
+		items[i] = BatchItem{
+			ID:                            "quarto-" + strconv.Itoa(i),
+			CalculateRoomPaintInCansInput: CalculateRoomPaintInCansInput{Walls: []WallInput{{Width: 4, Height: 2.5}}},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	emitted := 0
+	err := ExecuteBatch(ctx, NewCalculateRoomPaintInCans(), items, 4, func(result BatchResult) {
+		emitted++
+		if result.Error != "" {
+			t.Errorf("ExecuteBatch() %s = %+v, want canceled items left out", result.ID, result)
+		}
+		cancel()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ExecuteBatch() error = %v, want %v", err, context.Canceled)
+	}
+	if emitted == 0 || emitted >= len(items) {
+		t.Errorf("ExecuteBatch() emitted %d results, want some of %d", emitted, len(items))
+	}
+}
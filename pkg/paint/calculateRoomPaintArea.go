@@ -1,15 +1,23 @@
 package paint
 
 import (
+	"context"
 	"digitalrepublic/pkg/entities"
-	"errors"
+	"log/slog"
+	"sort"
 )
 
 const (
-	ExtraLargeCan = 18
-	LargeCan      = 3.6
-	MediumCan     = 2.5
-	SmallCan      = 0.5
+	ExtraLargeCan = entities.HugeCan
+	LargeCan      = entities.BigCan
+	MediumCan     = entities.MediumCan
+	SmallCan      = entities.SmallCan
+)
+
+const (
+	EnamelLargeCan  = entities.EnamelBigCan
+	EnamelMediumCan = entities.EnamelMediumCan
+	EnamelSmallCan  = entities.EnamelSmallCan
 )
 
 const (
@@ -18,38 +26,91 @@ const (
 	wallZeroError       = "é necessario pelo menos 1 parede"
 )
 
+const (
+	CodeDoorNegative   = "door_negative"
+	CodeWindowNegative = "window_negative"
+	CodeWallZero       = "wall_zero"
+)
+
 type WallInput struct {
-	Width          float64 `json:"width"`
-	Height         float64 `json:"height"`
-	DoorQuantity   int     `json:"door_quantity"`
-	WindowQuantity int     `json:"window_quantity"`
+	Width          float64     `json:"width" required:"true"`
+	Height         float64     `json:"height" required:"true"`
+	DoorQuantity   int         `json:"door_quantity"`
+	WindowQuantity int         `json:"window_quantity"`
+	Bands          []BandInput `json:"bands,omitempty"`
+	PaintDoors     bool        `json:"paint_doors"`
+	PaintFrames    bool        `json:"paint_frames"`
+	TrimLength     float64     `json:"trim_length"`
+}
+
+type BandInput struct {
+	From      float64 `json:"from"`
+	To        float64 `json:"to"`
+	Color     string  `json:"color"`
+	Unpainted bool    `json:"unpainted"`
 }
 
 type CalculateRoomPaintInCansOutput struct {
-	ExtraLargeCan int64 `json:"huge_can"`
-	LargeCan      int64 `json:"big_can"`
-	MediumCan     int64 `json:"medium_can"`
-	SmallCan      int64 `json:"small_can"`
+	ExtraLargeCan int64              `json:"huge_can"`
+	LargeCan      int64              `json:"big_can"`
+	MediumCan     int64              `json:"medium_can"`
+	SmallCan      int64              `json:"small_can"`
+	Bands         []BandPaintOutput  `json:"bands,omitempty"`
+	Enamel        *EnamelPaintOutput `json:"enamel,omitempty"`
+	Area          float64            `json:"area"`
+	Liters        float64            `json:"liters"`
+	Walls         []WallPaintOutput  `json:"walls"`
+}
+
+type WallPaintOutput struct {
+	Area   float64 `json:"area"`
+	Liters float64 `json:"liters"`
+}
+
+type EnamelPaintOutput struct {
+	LargeCan  int64 `json:"big_can"`
+	MediumCan int64 `json:"medium_can"`
+	SmallCan  int64 `json:"small_can"`
+}
+
+type BandPaintOutput struct {
+	Color         string `json:"color"`
+	ExtraLargeCan int64  `json:"huge_can"`
+	LargeCan      int64  `json:"big_can"`
+	MediumCan     int64  `json:"medium_can"`
+	SmallCan      int64  `json:"small_can"`
 }
 
 type CalculateRoomPaintInCansInput struct {
 	Walls []WallInput `json:"walls"`
 }
 
+// CalculateRoomPaintInCans calculates the cans needed to paint a room. Execute
+// returns the error of ctx, without calculating, once ctx is done.
 type CalculateRoomPaintInCans interface {
-	Execute(room CalculateRoomPaintInCansInput) (*CalculateRoomPaintInCansOutput, error)
+	Execute(ctx context.Context, room CalculateRoomPaintInCansInput) (*CalculateRoomPaintInCansOutput, error)
 }
 
 type calculateRoomPaintInCans struct {
+	logger *slog.Logger
 }
 
-func NewCalculateRoomPaintInCans() CalculateRoomPaintInCans {
-	return &calculateRoomPaintInCans{}
+// Option configures the interactor built by NewCalculateRoomPaintInCans.
+type Option func(*calculateRoomPaintInCans)
+
+// NewCalculateRoomPaintInCans builds the interactor once with its
+// dependencies; it is safe for concurrent use.
+func NewCalculateRoomPaintInCans(options ...Option) CalculateRoomPaintInCans {
+	i := &calculateRoomPaintInCans{logger: slog.Default()}
+	for _, option := range options {
+		option(i)
+	}
+	return i
 }
 
 func IsDoorNegative(door int) error {
 	if door < 0 {
-		return errors.New(negativeDoorError)
+		return entities.NewValidationError(CodeDoorNegative, negativeDoorError)
 	}
 	return nil
 }
@@ -59,14 +120,17 @@ func addWindowsAndDoorsToWall(room *entities.Room, input CalculateRoomPaintInCan
 	for in, wallInput := range input.Walls {
 
 		err := addDoorsToWall(&room.Walls[in], wallInput)
+		if entities.ValidationCode(err) == entities.CodeDoorHeight {
+			return atField(err, "/walls/%d/height", in)
+		}
 		if err != nil {
 
-			return err
+			return atField(err, "/walls/%d/door_quantity", in)
 		}
 
 		err = addWindowsToWall(&room.Walls[in], wallInput)
 		if err != nil {
-			return err
+			return atField(err, "/walls/%d/window_quantity", in)
 		}
 
 	}
@@ -83,8 +147,10 @@ func addDoorsToWall(wall *entities.Wall, input WallInput) error {
 	for i := 0; i < input.DoorQuantity; i++ {
 
 		doors := entities.Door{
-			Width:  entities.WidthDoor,
-			Height: entities.HeightDoor}
+			Width:        entities.WidthDoor,
+			Height:       entities.HeightDoor,
+			Painted:      input.PaintDoors,
+			FramePainted: input.PaintFrames}
 
 		wall.Doors = append(wall.Doors, doors)
 
@@ -98,7 +164,7 @@ func addDoorsToWall(wall *entities.Wall, input WallInput) error {
 }
 func IsWindowNegative(window int) error {
 	if window < 0 {
-		return errors.New(negativeWindowError)
+		return entities.NewValidationError(CodeWindowNegative, negativeWindowError)
 	}
 	return nil
 }
@@ -113,8 +179,10 @@ func addWindowsToWall(wall *entities.Wall, input WallInput) error {
 	for i := 0; i < input.WindowQuantity; i++ {
 
 		doors := entities.Window{
-			Width:  entities.WidthWindow,
-			Height: entities.HeightWindow}
+			Width:        entities.WidthWindow,
+			Height:       entities.HeightWindow,
+			Sill:         entities.SillHeightWindow,
+			FramePainted: input.PaintFrames}
 
 		wall.Windows = append(wall.Windows, doors)
 	}
@@ -128,20 +196,38 @@ func addWindowsToWall(wall *entities.Wall, input WallInput) error {
 func addWallsToRoom(room *entities.Room, input CalculateRoomPaintInCansInput) error {
 
 	if len(input.Walls) == 0 {
-		return errors.New(wallZeroError)
+		return atField(entities.NewValidationError(CodeWallZero, wallZeroError), "/walls")
 	}
 
-	for _, wallInput := range input.Walls {
+	for in, wallInput := range input.Walls {
+
+		width, err := entities.ParseMeters(wallInput.Width)
+		if err != nil {
+			return atField(err, "/walls/%d/width", in)
+		}
+		height, err := entities.ParseMeters(wallInput.Height)
+		if err != nil {
+			return atField(err, "/walls/%d/height", in)
+		}
+		trimLength, err := entities.ParseMeters(wallInput.TrimLength)
+		if err != nil {
+			return atField(err, "/walls/%d/trim_length", in)
+		}
+
+		wall, err := entities.NewWall(width, height)
 
-		wall, err := entities.NewWall(wallInput.Width, wallInput.Height)
+		if err != nil {
+			return atField(err, "/walls/%d%s", in, wallField(err))
+		}
 
+		err = wall.SetTrimLength(trimLength)
 		if err != nil {
-			return err
+			return atField(err, "/walls/%d/trim_length", in)
 		}
 
 		err = room.AddWall(wall)
 		if err != nil {
-			return err
+			return atField(err, "/walls")
 		}
 
 	}
@@ -150,6 +236,60 @@ func addWallsToRoom(room *entities.Room, input CalculateRoomPaintInCansInput) er
 		return err
 	}
 
+	err = addBandsToWalls(room, input)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// wallField is the field of the wall that a NewWall error points at. Area
+// errors point at the wall as a whole.
+func wallField(err error) string {
+	switch entities.ValidationCode(err) {
+	case entities.CodeWallWidthNegative:
+		return "/width"
+	case entities.CodeWallHeightNegative:
+		return "/height"
+	}
+	return ""
+}
+
+// ValidateWall checks a single wall with the rules Execute applies to each
+// wall, so a wall can be checked as soon as it is entered.
+func ValidateWall(input WallInput) error {
+	room := entities.Room{}
+	return addWallsToRoom(&room, CalculateRoomPaintInCansInput{Walls: []WallInput{input}})
+}
+
+func addBandsToWalls(room *entities.Room, input CalculateRoomPaintInCansInput) error {
+
+	for in, wallInput := range input.Walls {
+		for bn, bandInput := range wallInput.Bands {
+
+			from, err := entities.ParseMeters(bandInput.From)
+			if err != nil {
+				return atField(err, "/walls/%d/bands/%d/from", in, bn)
+			}
+			to, err := entities.ParseMeters(bandInput.To)
+			if err != nil {
+				return atField(err, "/walls/%d/bands/%d/to", in, bn)
+			}
+
+			band := entities.Band{
+				From:      from,
+				To:        to,
+				Color:     bandInput.Color,
+				Unpainted: bandInput.Unpainted}
+
+			err = room.Walls[in].AddBand(band)
+			if err != nil {
+				return atField(err, "/walls/%d/bands/%d", in, bn)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -174,7 +314,70 @@ func formatOutput(cans []entities.Can) CalculateRoomPaintInCansOutput {
 	return c
 }
 
-func (i *calculateRoomPaintInCans) Execute(input CalculateRoomPaintInCansInput) (*CalculateRoomPaintInCansOutput, error) {
+func formatBandsOutput(budget map[string][]entities.Can) []BandPaintOutput {
+	bands := []BandPaintOutput{}
+	for color, cans := range budget {
+		c := formatOutput(cans)
+		bands = append(bands, BandPaintOutput{
+			Color:         color,
+			ExtraLargeCan: c.ExtraLargeCan,
+			LargeCan:      c.LargeCan,
+			MediumCan:     c.MediumCan,
+			SmallCan:      c.SmallCan,
+		})
+	}
+
+	sort.Slice(bands, func(i, j int) bool {
+		return bands[i].Color < bands[j].Color
+	})
+	return bands
+}
+
+func formatEnamelOutput(cans []entities.Can) EnamelPaintOutput {
+	c := EnamelPaintOutput{}
+	for _, can := range cans {
+		switch can {
+		case EnamelLargeCan:
+			c.LargeCan += 1
+
+		case EnamelMediumCan:
+			c.MediumCan += 1
+
+		case EnamelSmallCan:
+			c.SmallCan += 1
+		}
+
+	}
+	return c
+}
+
+func formatWallsOutput(paintBudgetCalculator entities.PaintBudgetCalculator, room entities.Room) []WallPaintOutput {
+	walls := make([]WallPaintOutput, 0, len(room.Walls))
+	for _, wall := range room.Walls {
+		walls = append(walls, WallPaintOutput{
+			Area:   paintBudgetCalculator.CalculateWallPaintedArea(wall).SquareMeters(),
+			Liters: paintBudgetCalculator.CalculateWallPaintVolume(wall).Liters(),
+		})
+	}
+
+	return walls
+}
+
+func (i *calculateRoomPaintInCans) Execute(ctx context.Context, input CalculateRoomPaintInCansInput) (*CalculateRoomPaintInCansOutput, error) {
+	err := ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	logger := i.loggerFor(ctx)
+	logger.Debug("calculating room", "walls", len(input.Walls), "input", input)
+
+	result, err := calculate(input)
+	logCalculated(logger, result, err)
+	return result, err
+}
+
+func calculate(input CalculateRoomPaintInCansInput) (*CalculateRoomPaintInCansOutput, error) {
 
 	room := entities.Room{}
 	err := addWallsToRoom(&room, input)
@@ -184,5 +387,20 @@ func (i *calculateRoomPaintInCans) Execute(input CalculateRoomPaintInCansInput)
 	paintBudgetCalculator := entities.PaintBudgetCalculator{}
 	cans := paintBudgetCalculator.CalculatePaintBudget(room)
 	c := formatOutput(cans)
+
+	budgetByColor := paintBudgetCalculator.CalculatePaintBudgetByColor(room)
+	if len(budgetByColor) > 0 {
+		c.Bands = formatBandsOutput(budgetByColor)
+	}
+
+	enamelCans := paintBudgetCalculator.CalculateEnamelBudget(room)
+	if len(enamelCans) > 0 {
+		enamel := formatEnamelOutput(enamelCans)
+		c.Enamel = &enamel
+	}
+
+	c.Area = paintBudgetCalculator.CalculatePaintedArea(room).SquareMeters()
+	c.Liters = paintBudgetCalculator.CalculatePaintVolume(room).Liters()
+	c.Walls = formatWallsOutput(paintBudgetCalculator, room)
 	return &c, nil
 }
@@ -1,6 +1,7 @@
 package paint
 
 import (
+	"context"
 	"digitalrepublic/pkg/entities"
 	"reflect"
 	"testing"
@@ -88,8 +89,8 @@ func Test_addDoorsToWall(t *testing.T) {
 			name: "Should_ReturnPassedDoors_When_ValidParameters",
 			args: args{
 				wall: &entities.Wall{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
@@ -124,8 +125,8 @@ func Test_addDoorsToWall(t *testing.T) {
 			name: "Should_DoorsAreaInWallError_When_DoorsOverLimit",
 			args: args{
 				wall: &entities.Wall{
-					Width:   3,
-					Height:  3,
+					Width:   300,
+					Height:  300,
 					Doors:   nil,
 					Windows: nil,
 				},
@@ -142,8 +143,8 @@ func Test_addDoorsToWall(t *testing.T) {
 			name: "Should_MaxDoorHeightError_When_DoorsOverLimitHeight",
 			args: args{
 				wall: &entities.Wall{
-					Width:   5,
-					Height:  2,
+					Width:   500,
+					Height:  200,
 					Doors:   nil,
 					Windows: nil,
 				},
@@ -180,8 +181,8 @@ func Test_addWindowsToWall(t *testing.T) {
 			name: "Should_ReturnPassedWindows_When_ValidParameters",
 			args: args{
 				wall: &entities.Wall{
-					Width:   5,
-					Height:  5,
+					Width:   500,
+					Height:  500,
 					Doors:   nil,
 					Windows: nil,
 				},
@@ -216,8 +217,8 @@ func Test_addWindowsToWall(t *testing.T) {
 			name: "Should_WindowsAreaInWallError_When_WindowsOverLimit",
 			args: args{
 				wall: &entities.Wall{
-					Width:   3,
-					Height:  3,
+					Width:   300,
+					Height:  300,
 					Doors:   nil,
 					Windows: nil,
 				},
@@ -254,7 +255,7 @@ func Test_addWallsToRoom(t *testing.T) {
 			name: "Should_ReturnPassedWall_When_ValidParameters",
 			args: args{
 				room: &entities.Room{Walls: []entities.Wall{
-					{Width: 5, Height: 5, Doors: nil, Windows: nil},
+					{Width: 500, Height: 500, Doors: nil, Windows: nil},
 				}},
 				input: CalculateRoomPaintInCansInput{
 					[]WallInput{{
@@ -285,6 +286,47 @@ func Test_addWallsToRoom(t *testing.T) {
 	}
 }
 
+func TestValidateWall(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   WallInput
+		wantErr bool
+	}{
+		{
+			name:    "Should_ReturnNil_When_WallIsValid",
+			input:   WallInput{Width: 5, Height: 2.5, DoorQuantity: 1, WindowQuantity: 1, TrimLength: 4},
+			wantErr: false,
+		},
+		{
+			name:    "Should_WallAreaLimitError_When_WallIsTooSmall",
+			input:   WallInput{Width: 0.5, Height: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Should_MaxDoorHeightError_When_WallIsTooLowForDoor",
+			input:   WallInput{Width: 5, Height: 2, DoorQuantity: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Should_NegativeWindowError_When_WindowsAreNegative",
+			input:   WallInput{Width: 5, Height: 2.5, WindowQuantity: -1},
+			wantErr: true,
+		},
+		{
+			name:    "Should_TrimLimitError_When_TrimIsLongerThanWall",
+			input:   WallInput{Width: 3, Height: 2.5, TrimLength: 4},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateWall(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWall() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_formatOutput(t *testing.T) {
 	type args struct {
 		cans []entities.Can
@@ -325,3 +367,128 @@ func Test_formatOutput(t *testing.T) {
 		})
 	}
 }
+
+func Test_addBandsToWalls(t *testing.T) {
+	type args struct {
+		room  *entities.Room
+		input CalculateRoomPaintInCansInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Should_ReturnPassedBands_When_ValidParameters",
+			args: args{
+				room: &entities.Room{Walls: []entities.Wall{
+					{Width: 500, Height: 250},
+				}},
+				input: CalculateRoomPaintInCansInput{
+					[]WallInput{{
+						Width:  5,
+						Height: 2.5,
+						Bands: []BandInput{
+							{From: 0, To: 1, Color: "azul"},
+							{From: 1, To: 2.5, Unpainted: true},
+						},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should_BandOverlapError_When_BandsOverlap",
+			args: args{
+				room: &entities.Room{Walls: []entities.Wall{
+					{Width: 500, Height: 250},
+				}},
+				input: CalculateRoomPaintInCansInput{
+					[]WallInput{{
+						Width:  5,
+						Height: 2.5,
+						Bands: []BandInput{
+							{From: 0, To: 1.5, Color: "azul"},
+							{From: 1, To: 2.5, Color: "verde"},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := addBandsToWalls(tt.args.room, tt.args.input); (err != nil) != tt.wantErr {
+				t.Errorf("addBandsToWalls() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_formatBandsOutput(t *testing.T) {
+	budget := map[string][]entities.Can{
+		"verde": {LargeCan},
+		"azul":  {SmallCan, SmallCan},
+	}
+	want := []BandPaintOutput{
+		{Color: "azul", SmallCan: 2},
+		{Color: "verde", LargeCan: 1},
+	}
+	if got := formatBandsOutput(budget); !reflect.DeepEqual(got, want) {
+		t.Errorf("formatBandsOutput() = %v, want %v", got, want)
+	}
+}
+
+func Test_formatEnamelOutput(t *testing.T) {
+	cans := []entities.Can{EnamelLargeCan, EnamelMediumCan, EnamelSmallCan, EnamelSmallCan}
+	want := EnamelPaintOutput{LargeCan: 1, MediumCan: 1, SmallCan: 2}
+	if got := formatEnamelOutput(cans); !reflect.DeepEqual(got, want) {
+		t.Errorf("formatEnamelOutput() = %v, want %v", got, want)
+	}
+}
+
+func Test_calculateRoomPaintInCans_Execute(t *testing.T) {
+	wall := WallInput{Width: 5, Height: 5, DoorQuantity: 1, WindowQuantity: 1}
+	tests := []struct {
+		name    string
+		input   CalculateRoomPaintInCansInput
+		want    *CalculateRoomPaintInCansOutput
+		wantErr bool
+	}{
+		{
+			name:  "Should_ReturnPassedCans_When_4WallParameters",
+			input: CalculateRoomPaintInCansInput{Walls: []WallInput{wall, wall, wall, wall}},
+			want: &CalculateRoomPaintInCansOutput{LargeCan: 4, SmallCan: 5, Area: 84.32, Liters: 16.864, Walls: []WallPaintOutput{
+				{Area: 21.08, Liters: 4.216}, {Area: 21.08, Liters: 4.216}, {Area: 21.08, Liters: 4.216}, {Area: 21.08, Liters: 4.216},
+			}},
+		},
+		{
+			name:  "Should_RoundUpToSmallCan_When_RemainderBelowSmallCan",
+			input: CalculateRoomPaintInCansInput{Walls: []WallInput{{Width: 4.5, Height: 4}}},
+			want:  &CalculateRoomPaintInCansOutput{LargeCan: 1, Area: 18, Liters: 3.6, Walls: []WallPaintOutput{{Area: 18, Liters: 3.6}}},
+		},
+		{
+			name:  "Should_ReturnPassedCans_When_DecimalMeasures",
+			input: CalculateRoomPaintInCansInput{Walls: []WallInput{{Width: 3.6, Height: 2.5}}},
+			want:  &CalculateRoomPaintInCansOutput{SmallCan: 4, Area: 9, Liters: 1.8, Walls: []WallPaintOutput{{Area: 9, Liters: 1.8}}},
+		},
+		{
+			name:    "Should_WallZeroError_When_ZeroParameters",
+			input:   CalculateRoomPaintInCansInput{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCalculateRoomPaintInCans().Execute(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Execute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
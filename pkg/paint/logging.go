@@ -0,0 +1,58 @@
+package paint
+
+import (
+	"context"
+	"digitalrepublic/pkg/entities"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// ContextWithLogger returns a copy of ctx whose calculations log through
+// logger instead of the one the interactor was built with, e.g. one carrying
+// the request ID.
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// ContextLogger returns the logger set by ContextWithLogger, or
+// slog.Default() when there is none.
+func ContextLogger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// WithLogger sets the logger of the input and the outcome of each
+// calculation, written at debug level. slog.Default() is used otherwise.
+func WithLogger(logger *slog.Logger) Option {
+	return func(i *calculateRoomPaintInCans) {
+		i.logger = logger
+	}
+}
+
+func (i *calculateRoomPaintInCans) loggerFor(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return i.logger
+}
+
+func logCalculated(logger *slog.Logger, result *CalculateRoomPaintInCansOutput, err error) {
+	if err != nil {
+		logger.Debug("room rejected", "code", entities.ValidationCode(err), "error", err)
+		return
+	}
+
+	logger.Debug("room calculated",
+		"area", result.Area,
+		"liters", result.Liters,
+		"huge_can", result.ExtraLargeCan,
+		"big_can", result.LargeCan,
+		"medium_can", result.MediumCan,
+		"small_can", result.SmallCan,
+		"bands", len(result.Bands),
+		"enamel", result.Enamel != nil,
+	)
+}
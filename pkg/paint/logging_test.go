@@ -0,0 +1,49 @@
+package paint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	var ignored, out bytes.Buffer
+	built := slog.New(slog.NewJSONHandler(&ignored, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})).With("request_id", "abc")
+	interactor := NewCalculateRoomPaintInCans(WithLogger(built))
+	ctx := ContextWithLogger(context.Background(), logger)
+
+	_, _ = interactor.Execute(ctx, CalculateRoomPaintInCansInput{Walls: []WallInput{{Width: 4, Height: 2.5}}})
+	_, _ = interactor.Execute(ctx, CalculateRoomPaintInCansInput{})
+	if ignored.Len() != 0 {
+		t.Errorf("Execute() logged %s through the built logger, want the logger of the context", ignored.String())
+	}
+
+	var lines []map[string]interface{}
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var line map[string]interface{}
+		if err := decoder.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+
+	want := []string{"calculating room", "room calculated", "calculating room", "room rejected"}
+	if len(lines) != len(want) {
+		t.Fatalf("Execute() wrote %d lines, want %d", len(lines), len(want))
+	}
+	for i, line := range lines {
+		if line["msg"] != want[i] || line["request_id"] != "abc" || line["level"] != "DEBUG" {
+			t.Errorf("line %d = %v, want %q at debug with the request id", i, line, want[i])
+		}
+	}
+	if lines[1]["liters"] != 2.0 {
+		t.Errorf("liters = %v, want 2", lines[1]["liters"])
+	}
+	if lines[3]["code"] != CodeWallZero {
+		t.Errorf("code = %v, want %v", lines[3]["code"], CodeWallZero)
+	}
+}
@@ -0,0 +1,40 @@
+package paint
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FieldError locates an error in the input with a JSON pointer, such as
+// /walls/2/door_quantity. It keeps the message and code of the wrapped error.
+type FieldError struct {
+	Pointer string
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Pointer returns the JSON pointer of the field that caused err, or an empty
+// string when err does not point at a field.
+func Pointer(err error) string {
+	var field *FieldError
+	if errors.As(err, &field) {
+		return field.Pointer
+	}
+	return ""
+}
+
+// atField wraps err with the pointer built from format and args. A nil err
+// stays nil.
+func atField(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &FieldError{Pointer: fmt.Sprintf(format, args...), Err: err}
+}
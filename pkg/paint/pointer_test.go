@@ -0,0 +1,89 @@
+package paint
+
+import (
+	"context"
+	"digitalrepublic/pkg/entities"
+	"testing"
+)
+
+func TestPointer(t *testing.T) {
+	valid := WallInput{Width: 5, Height: 2.5}
+
+	tests := []struct {
+		name     string
+		walls    []WallInput
+		want     string
+		wantCode string
+	}{
+		{
+			name:     "Should_PointAtWalls_When_ThereAreNoWalls",
+			want:     "/walls",
+			wantCode: CodeWallZero,
+		},
+		{
+			name:     "Should_PointAtWidth_When_WidthIsNegative",
+			walls:    []WallInput{valid, {Width: -1, Height: 2.5}},
+			want:     "/walls/1/width",
+			wantCode: entities.CodeWallWidthNegative,
+		},
+		{
+			name:     "Should_PointAtWall_When_AreaIsTooSmall",
+			walls:    []WallInput{{Width: 0.5, Height: 1}},
+			want:     "/walls/0",
+			wantCode: entities.CodeWallAreaLimit,
+		},
+		{
+			name:     "Should_PointAtDoorQuantity_When_DoorsAreNegative",
+			walls:    []WallInput{valid, valid, {Width: 5, Height: 2.5, DoorQuantity: -1}},
+			want:     "/walls/2/door_quantity",
+			wantCode: CodeDoorNegative,
+		},
+		{
+			name:     "Should_PointAtHeight_When_WallIsTooLowForDoor",
+			walls:    []WallInput{{Width: 5, Height: 2, DoorQuantity: 1}},
+			want:     "/walls/0/height",
+			wantCode: entities.CodeDoorHeight,
+		},
+		{
+			name:     "Should_PointAtHeight_When_HeightIsFinerThanACentimeter",
+			walls:    []WallInput{{Width: 5, Height: 2.195, DoorQuantity: 1}},
+			want:     "/walls/0/height",
+			wantCode: entities.CodeMeasurePrecision,
+		},
+		{
+			name:     "Should_PointAtBandLimit_When_LimitIsFinerThanACentimeter",
+			walls:    []WallInput{{Width: 5, Height: 2.5, Bands: []BandInput{{From: 0, To: 1.005, Color: "azul"}}}},
+			want:     "/walls/0/bands/0/to",
+			wantCode: entities.CodeMeasurePrecision,
+		},
+		{
+			name:     "Should_PointAtWindowQuantity_When_WindowsDoNotFit",
+			walls:    []WallInput{{Width: 2, Height: 2.5, WindowQuantity: 5}},
+			want:     "/walls/0/window_quantity",
+			wantCode: entities.CodeOpeningsAreaLimit,
+		},
+		{
+			name:     "Should_PointAtTrimLength_When_TrimIsLongerThanWall",
+			walls:    []WallInput{{Width: 3, Height: 2.5, TrimLength: 4}},
+			want:     "/walls/0/trim_length",
+			wantCode: entities.CodeTrimLimit,
+		},
+		{
+			name:     "Should_PointAtBand_When_BandIsOutOfOrder",
+			walls:    []WallInput{{Width: 5, Height: 2.5, Bands: []BandInput{{From: 0, To: 1, Color: "azul"}, {From: 2, To: 1.5, Color: "azul"}}}},
+			want:     "/walls/0/bands/1",
+			wantCode: entities.CodeBandOrder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCalculateRoomPaintInCans().Execute(context.Background(), CalculateRoomPaintInCansInput{Walls: tt.walls})
+			if got := Pointer(err); got != tt.want {
+				t.Errorf("Pointer() = %q, want %q (error %v)", got, tt.want, err)
+			}
+			if got := entities.ValidationCode(err); got != tt.wantCode {
+				t.Errorf("ValidationCode() = %q, want %q", got, tt.wantCode)
+			}
+		})
+	}
+}
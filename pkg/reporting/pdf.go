@@ -0,0 +1,212 @@
+package reporting
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page in points, the unit used by PDF.
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	pageMargin   = 50.0
+	headerHeight = 70.0
+	lineHeight   = 16.0
+)
+
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+const dateLayout = "02/01/2006"
+
+// brandColor is the RGB fill of the header bar, each channel from 0 to 1.
+var brandColor = [3]float64{0.13, 0.39, 0.65}
+
+// RenderPDF writes the quote as a PDF 1.4 document. Text is laid out top to
+// bottom in the standard Helvetica fonts and flows onto new pages as needed,
+// so no font or image has to be embedded.
+func RenderPDF(w io.Writer, q Quote) error {
+	l := newLayout(q.Brand)
+
+	l.text(fontBold, 12, pageMargin, "Orçamento nº "+q.Number)
+	l.text(fontRegular, 10, pageMargin, "Emitido em "+q.IssuedAt.Format(dateLayout))
+	l.text(fontRegular, 10, pageMargin, "Válido até "+q.ValidUntil.Format(dateLayout))
+	l.space()
+
+	for _, room := range q.Rooms {
+		l.text(fontBold, 12, pageMargin, room.Name)
+		l.columns(fontBold, 10, []column{
+			{pageMargin, "Parede"}, {130, "Medidas"}, {250, "Portas"}, {320, "Janelas"}, {400, "Área pintada"},
+		})
+		for i, wall := range room.Walls {
+			l.columns(fontRegular, 10, []column{
+				{pageMargin, fmt.Sprintf("%d", i+1)},
+				{130, fmt.Sprintf("%s x %s m", formatDecimal(wall.Width, 2), formatDecimal(wall.Height, 2))},
+				{250, fmt.Sprintf("%d", wall.Doors)},
+				{320, fmt.Sprintf("%d", wall.Windows)},
+				{400, formatDecimal(wall.Area, 2) + " m²"},
+			})
+		}
+		l.text(fontRegular, 10, pageMargin, fmt.Sprintf("Área total: %s m²   Tinta necessária: %s L",
+			formatDecimal(room.Area, 2), formatDecimal(room.Liters, 3)))
+		l.space()
+	}
+
+	l.columns(fontBold, 10, []column{
+		{pageMargin, "Item"}, {300, "Qtd."}, {360, "Preço unitário"}, {460, "Total"},
+	})
+	for _, line := range q.Lines {
+		l.columns(fontRegular, 10, []column{
+			{pageMargin, line.Description},
+			{300, fmt.Sprintf("%d", line.Quantity)},
+			{360, q.Catalog.Format(line.UnitPrice)},
+			{460, q.Catalog.Format(line.Total)},
+		})
+	}
+	l.space()
+	l.columns(fontBold, 12, []column{{pageMargin, "Total"}, {460, q.Catalog.Format(q.Total)}})
+
+	return l.write(w)
+}
+
+type column struct {
+	x    float64
+	text string
+}
+
+type layout struct {
+	brand string
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func newLayout(brand string) *layout {
+	l := &layout{brand: brand}
+	l.newPage()
+	return l
+}
+
+// newPage starts a page with the brand header bar and moves the cursor below it.
+func (l *layout) newPage() {
+	page := &bytes.Buffer{}
+	fmt.Fprintf(page, "%.2f %.2f %.2f rg\n", brandColor[0], brandColor[1], brandColor[2])
+	fmt.Fprintf(page, "0 %.2f %.2f %.2f re f\n", pageHeight-headerHeight, pageWidth, headerHeight)
+	fmt.Fprintf(page, "1 1 1 rg\n")
+	writeText(page, fontBold, 20, pageMargin, pageHeight-headerHeight/2-7, l.brand)
+	fmt.Fprintf(page, "0 0 0 rg\n")
+
+	l.pages = append(l.pages, page)
+	l.y = pageHeight - headerHeight - 2*lineHeight
+}
+
+func (l *layout) nextLine() *bytes.Buffer {
+	if l.y < pageMargin {
+		l.newPage()
+	}
+
+	return l.pages[len(l.pages)-1]
+}
+
+func (l *layout) text(font string, size, x float64, text string) {
+	l.columns(font, size, []column{{x, text}})
+}
+
+func (l *layout) columns(font string, size float64, columns []column) {
+	page := l.nextLine()
+	for _, c := range columns {
+		writeText(page, font, size, c.x, l.y, c.text)
+	}
+	l.y -= lineHeight
+}
+
+func (l *layout) space() {
+	l.y -= lineHeight / 2
+}
+
+func writeText(page *bytes.Buffer, font string, size, x, y float64, text string) {
+	fmt.Fprintf(page, "BT /%s %.0f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escapeText(text))
+}
+
+// write serializes the objects and the cross-reference table. Objects 1 to 4
+// are the catalog, the page tree and the two fonts; each page then takes two
+// objects, the page itself and its content stream.
+func (l *layout) write(w io.Writer) error {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	kids := make([]string, len(l.pages))
+	for i := range l.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(l.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range l.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// escapeText encodes text in WinAnsiEncoding, the encoding declared for the
+// fonts, escaping the characters that are special inside a PDF string.
+func escapeText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		c, ok := winAnsi(r)
+		switch {
+		case !ok:
+			b.WriteByte('?')
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+func winAnsi(r rune) (byte, bool) {
+	if c, ok := winAnsiSpecials[r]; ok {
+		return c, true
+	}
+	if r < 0x80 || (r >= 0xa0 && r <= 0xff) {
+		return byte(r), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,173 @@
+package reporting
+
+import (
+	"bytes"
+	"digitalrepublic/pkg/catalog"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/paint"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	startXrefPattern = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	textPattern      = regexp.MustCompile(`\(((?:\\.|[^\\)])*)\) Tj`)
+	pagesPattern     = regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`)
+)
+
+// parsePDF checks the cross-reference table of the document and returns the
+// text shown on its pages, decoded back from WinAnsiEncoding.
+func parsePDF(t *testing.T, data []byte) (pages int, texts []string) {
+	t.Helper()
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header: %q", data[:16])
+	}
+
+	match := startXrefPattern.FindSubmatch(data)
+	if match == nil {
+		t.Fatalf("missing startxref trailer")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point to the xref table", xref)
+	}
+
+	lines := strings.Split(string(data[xref:]), "\n")
+	var size int
+	fmt.Sscanf(lines[1], "0 %d", &size)
+	for i := 1; i < size; i++ {
+		offset, _ := strconv.Atoi(lines[2+i][:10])
+		want := fmt.Sprintf("%d 0 obj\n", i)
+		if !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points to %q, want %q", i, data[offset:offset+10], want)
+		}
+	}
+
+	count := pagesPattern.FindSubmatch(data)
+	if count == nil {
+		t.Fatalf("missing page tree")
+	}
+	pages, _ = strconv.Atoi(string(count[1]))
+
+	for _, m := range textPattern.FindAllSubmatch(data, -1) {
+		texts = append(texts, decodeText(string(m[1])))
+	}
+	return pages, texts
+}
+
+func decodeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' {
+			i++
+			if s[i] >= '0' && s[i] <= '7' {
+				n, _ := strconv.ParseUint(s[i:i+3], 8, 8)
+				c = byte(n)
+				i += 2
+			} else {
+				c = s[i]
+			}
+		}
+		b.WriteRune(rune(c))
+	}
+	return b.String()
+}
+
+func newTestQuote(walls int) Quote {
+	wall := paint.WallInput{Width: 5, Height: 5, DoorQuantity: 1, WindowQuantity: 1}
+	revision := estimate.Revision{
+		Number: 2,
+		Input:  paint.CalculateRoomPaintInCansInput{},
+		Result: paint.CalculateRoomPaintInCansOutput{
+			LargeCan: 1, SmallCan: 2, Area: 21.08, Liters: 4.216,
+			Bands:  []paint.BandPaintOutput{{Color: "azul", SmallCan: 1}},
+			Enamel: &paint.EnamelPaintOutput{SmallCan: 2},
+		},
+	}
+	for i := 0; i < walls; i++ {
+		revision.Input.Walls = append(revision.Input.Walls, wall)
+		revision.Result.Walls = append(revision.Result.Walls, paint.WallPaintOutput{Area: 21.08, Liters: 4.216})
+	}
+
+	issuedAt := time.Date(2022, 11, 20, 0, 0, 0, 0, time.UTC)
+	return NewQuote(estimate.Estimate{ID: "abc123"}, revision, catalog.Default(), issuedAt)
+}
+
+func TestNewQuote(t *testing.T) {
+	q := newTestQuote(1)
+
+	if q.Number != "abc123-2" || !q.ValidUntil.Equal(time.Date(2022, 12, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NewQuote() number = %v, valid until = %v", q.Number, q.ValidUntil)
+	}
+
+	want := []Line{
+		{Description: "Tinta de parede 3,6L", Quantity: 1, UnitPrice: 9990, Total: 9990},
+		{Description: "Tinta de parede 0,5L", Quantity: 2, UnitPrice: 2490, Total: 4980},
+		{Description: "Tinta de parede azul 0,5L", Quantity: 1, UnitPrice: 2490, Total: 2490},
+		{Description: "Esmalte 0,225L", Quantity: 2, UnitPrice: 1990, Total: 3980},
+	}
+	if fmt.Sprint(q.Lines) != fmt.Sprint(want) {
+		t.Errorf("NewQuote() lines = %v, want %v", q.Lines, want)
+	}
+	if q.Total != 21440 {
+		t.Errorf("NewQuote() total = %v, want 21440", q.Total)
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	var out bytes.Buffer
+	err := RenderPDF(&out, newTestQuote(1))
+	if err != nil {
+		t.Fatalf("RenderPDF() error = %v", err)
+	}
+
+	pages, texts := parsePDF(t, out.Bytes())
+	if pages != 1 {
+		t.Errorf("RenderPDF() pages = %d, want 1", pages)
+	}
+
+	text := strings.Join(texts, "\n")
+	for _, want := range []string{
+		DefaultBrand,
+		"Orçamento nº abc123-2",
+		"Válido até 20/12/2022",
+		"5,00 x 5,00 m",
+		"21,08 m²",
+		"Tinta de parede azul 0,5L",
+		"R$ 214,40",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("RenderPDF() text does not contain %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestRenderPDF_FlowsOntoNewPages(t *testing.T) {
+	var out bytes.Buffer
+	err := RenderPDF(&out, newTestQuote(60))
+	if err != nil {
+		t.Fatalf("RenderPDF() error = %v", err)
+	}
+
+	pages, texts := parsePDF(t, out.Bytes())
+	if pages < 2 {
+		t.Errorf("RenderPDF() pages = %d, want more than 1", pages)
+	}
+	if texts[len(texts)-1] != "R$ 214,40" {
+		t.Errorf("RenderPDF() last text = %q, want the total", texts[len(texts)-1])
+	}
+}
+
+func Test_escapeText(t *testing.T) {
+	got := escapeText(`Área (sala) \ 5€ ✓`)
+	want := `\301rea \(sala\) \\ 5\200 ?`
+	if got != want {
+		t.Errorf("escapeText() = %v, want %v", got, want)
+	}
+}
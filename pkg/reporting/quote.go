@@ -0,0 +1,132 @@
+package reporting
+
+import (
+	"digitalrepublic/pkg/catalog"
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/paint"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBrand  = "Paint Calculator"
+	QuoteValidity = 30 * 24 * time.Hour
+)
+
+// Quote is everything printed on the document handed to the customer. It is
+// built from an estimate revision and a price catalog by NewQuote.
+type Quote struct {
+	Brand      string
+	Number     string
+	IssuedAt   time.Time
+	ValidUntil time.Time
+	Rooms      []Room
+	Lines      []Line
+	Total      catalog.Cents
+	Catalog    catalog.Catalog
+}
+
+type Room struct {
+	Name   string
+	Walls  []Wall
+	Area   float64
+	Liters float64
+}
+
+type Wall struct {
+	Width   float64
+	Height  float64
+	Doors   int
+	Windows int
+	Area    float64
+}
+
+type Line struct {
+	Description string
+	Quantity    int64
+	UnitPrice   catalog.Cents
+	Total       catalog.Cents
+}
+
+func NewQuote(e estimate.Estimate, revision estimate.Revision, c catalog.Catalog, issuedAt time.Time) Quote {
+	q := Quote{
+		Brand:      DefaultBrand,
+		Number:     fmt.Sprintf("%s-%d", e.ID, revision.Number),
+		IssuedAt:   issuedAt,
+		ValidUntil: issuedAt.Add(QuoteValidity),
+		Catalog:    c,
+	}
+
+	q.Rooms = []Room{newRoom("Ambiente", revision.Input, revision.Result)}
+
+	result := revision.Result
+	q.addWallPaintLines("Tinta de parede", paint.BandPaintOutput{
+		ExtraLargeCan: result.ExtraLargeCan,
+		LargeCan:      result.LargeCan,
+		MediumCan:     result.MediumCan,
+		SmallCan:      result.SmallCan,
+	})
+	for _, band := range result.Bands {
+		q.addWallPaintLines("Tinta de parede "+band.Color, band)
+	}
+	if result.Enamel != nil {
+		q.addLine("Esmalte", entities.EnamelBigCan, result.Enamel.LargeCan, c.Enamel)
+		q.addLine("Esmalte", entities.EnamelMediumCan, result.Enamel.MediumCan, c.Enamel)
+		q.addLine("Esmalte", entities.EnamelSmallCan, result.Enamel.SmallCan, c.Enamel)
+	}
+
+	return q
+}
+
+func newRoom(name string, input paint.CalculateRoomPaintInCansInput, result paint.CalculateRoomPaintInCansOutput) Room {
+	room := Room{Name: name, Area: result.Area, Liters: result.Liters}
+	for i, wallInput := range input.Walls {
+		wall := Wall{
+			Width:   wallInput.Width,
+			Height:  wallInput.Height,
+			Doors:   wallInput.DoorQuantity,
+			Windows: wallInput.WindowQuantity,
+		}
+		if i < len(result.Walls) {
+			wall.Area = result.Walls[i].Area
+		}
+		room.Walls = append(room.Walls, wall)
+	}
+
+	return room
+}
+
+func (q *Quote) addWallPaintLines(description string, cans paint.BandPaintOutput) {
+	q.addLine(description, entities.HugeCan, cans.ExtraLargeCan, q.Catalog.WallPaint)
+	q.addLine(description, entities.BigCan, cans.LargeCan, q.Catalog.WallPaint)
+	q.addLine(description, entities.MediumCan, cans.MediumCan, q.Catalog.WallPaint)
+	q.addLine(description, entities.SmallCan, cans.SmallCan, q.Catalog.WallPaint)
+}
+
+func (q *Quote) addLine(description string, can entities.Can, quantity int64, prices map[entities.Can]catalog.Cents) {
+	if quantity == 0 {
+		return
+	}
+
+	unitPrice := prices[can]
+	line := Line{
+		Description: fmt.Sprintf("%s %s", description, formatCan(can)),
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Total:       unitPrice * catalog.Cents(quantity),
+	}
+
+	q.Lines = append(q.Lines, line)
+	q.Total += line.Total
+}
+
+func formatCan(can entities.Can) string {
+	return formatDecimal(can.Liters(), -1) + "L"
+}
+
+func formatDecimal(value float64, precision int) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', precision, 64), ".", ",", 1)
+}
@@ -0,0 +1,141 @@
+// Package service holds the operations of the calculator behind every
+// transport, so the REST handlers and the JSON-RPC endpoint return the same
+// results and errors.
+package service
+
+import (
+	"context"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/paint"
+	"runtime"
+)
+
+// Service calculates rooms and keeps them as estimates. Errors are returned
+// as the domain produces them: validation errors pointing at the offending
+// field, estimate.ErrNotFound and estimate.ErrRevisionNotFound.
+type Service struct {
+	interactor   paint.CalculateRoomPaintInCans
+	estimates    estimate.Repository
+	batchWorkers int
+}
+
+func New(interactor paint.CalculateRoomPaintInCans, estimates estimate.Repository) *Service {
+	return &Service{interactor: interactor, estimates: estimates, batchWorkers: runtime.NumCPU()}
+}
+
+// Calculate returns the cans needed to paint the room.
+func (s *Service) Calculate(ctx context.Context, room paint.CalculateRoomPaintInCansInput) (*paint.CalculateRoomPaintInCansOutput, error) {
+	return s.interactor.Execute(ctx, room)
+}
+
+// Batch checks the batch and calculates its rooms, calling emit with each
+// result as soon as it is ready. emit is never called concurrently. Once ctx
+// is done the rooms left are skipped and the error of ctx is returned.
+func (s *Service) Batch(ctx context.Context, rooms []paint.BatchItem, emit func(paint.BatchResult)) error {
+	err := paint.ValidateBatch(rooms)
+	if err != nil {
+		return err
+	}
+
+	return paint.ExecuteBatch(ctx, s.interactor, rooms, s.batchWorkers, emit)
+}
+
+// CreateEstimate calculates the room and saves it as a new estimate.
+func (s *Service) CreateEstimate(ctx context.Context, room paint.CalculateRoomPaintInCansInput) (estimate.Estimate, error) {
+	result, err := s.Calculate(ctx, room)
+	if err != nil {
+		return estimate.Estimate{}, err
+	}
+
+	return s.estimates.Create(estimate.Estimate{Input: room, Result: *result})
+}
+
+func (s *Service) ListEstimates(ctx context.Context) ([]estimate.Estimate, error) {
+	return s.estimates.List()
+}
+
+func (s *Service) GetEstimate(ctx context.Context, id string) (estimate.Estimate, error) {
+	return s.estimates.Get(id)
+}
+
+// UpdateEstimate recalculates the estimate with a new room, adding a revision.
+func (s *Service) UpdateEstimate(ctx context.Context, id string, room paint.CalculateRoomPaintInCansInput) (estimate.Estimate, error) {
+	_, err := s.estimates.Get(id)
+	if err != nil {
+		return estimate.Estimate{}, err
+	}
+
+	result, err := s.Calculate(ctx, room)
+	if err != nil {
+		return estimate.Estimate{}, err
+	}
+
+	return s.estimates.Update(estimate.Estimate{ID: id, Input: room, Result: *result})
+}
+
+func (s *Service) DeleteEstimate(ctx context.Context, id string) error {
+	return s.estimates.Delete(id)
+}
+
+func (s *Service) ListRevisions(ctx context.Context, id string) ([]estimate.Revision, error) {
+	found, err := s.estimates.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return found.Revisions, nil
+}
+
+// Revision returns the estimate with one of its revisions, numbered from 1.
+func (s *Service) Revision(ctx context.Context, id string, number int) (estimate.Estimate, estimate.Revision, error) {
+	found, err := s.estimates.Get(id)
+	if err != nil {
+		return estimate.Estimate{}, estimate.Revision{}, err
+	}
+
+	revision, err := found.Revision(number)
+	return found, revision, err
+}
+
+// LatestRevision returns the estimate with its latest revision.
+func (s *Service) LatestRevision(ctx context.Context, id string) (estimate.Estimate, estimate.Revision, error) {
+	found, err := s.estimates.Get(id)
+	if err != nil {
+		return estimate.Estimate{}, estimate.Revision{}, err
+	}
+
+	revision, err := found.LatestRevision()
+	return found, revision, err
+}
+
+// DiffRevisions compares two revisions of the estimate, numbered from 1.
+// Without to the latest revision is used, and without from the one before
+// to, or to itself when it is the first.
+func (s *Service) DiffRevisions(ctx context.Context, id string, from, to *int) (estimate.RevisionDiff, error) {
+	found, err := s.estimates.Get(id)
+	if err != nil {
+		return estimate.RevisionDiff{}, err
+	}
+
+	toNumber := len(found.Revisions)
+	if to != nil {
+		toNumber = *to
+	}
+	toRevision, err := found.Revision(toNumber)
+	if err != nil {
+		return estimate.RevisionDiff{}, err
+	}
+
+	fromNumber := toNumber - 1
+	if fromNumber < 1 {
+		fromNumber = 1
+	}
+	if from != nil {
+		fromNumber = *from
+	}
+	fromRevision, err := found.Revision(fromNumber)
+	if err != nil {
+		return estimate.RevisionDiff{}, err
+	}
+
+	return estimate.Diff(fromRevision, toRevision), nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"digitalrepublic/pkg/entities"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/paint"
+	"errors"
+	"testing"
+)
+
+func room(width float64) paint.CalculateRoomPaintInCansInput {
+	return paint.CalculateRoomPaintInCansInput{Walls: []paint.WallInput{{Width: width, Height: 2.5}}}
+}
+
+// newEstimate saves an estimate with one revision per width.
+func newEstimate(t *testing.T, s *Service, widths ...float64) string {
+	ctx := context.Background()
+	created, err := s.CreateEstimate(ctx, room(widths[0]))
+	if err != nil {
+		t.Fatalf("CreateEstimate() error = %v", err)
+	}
+	for _, width := range widths[1:] {
+		if _, err := s.UpdateEstimate(ctx, created.ID, room(width)); err != nil {
+			t.Fatalf("UpdateEstimate() error = %v", err)
+		}
+	}
+	return created.ID
+}
+
+func TestService_Revision(t *testing.T) {
+	s := New(paint.NewCalculateRoomPaintInCans(), estimate.NewMemoryRepository())
+	id := newEstimate(t, s, 5, 4, 3)
+
+	tests := []struct {
+		name    string
+		number  int
+		want    int
+		wantErr error
+	}{
+		{name: "Should_ReturnNotFound_When_NumberIsZero", number: 0, wantErr: estimate.ErrRevisionNotFound},
+		{name: "Should_ReturnRevision_When_NumberExists", number: 2, want: 2},
+		{name: "Should_ReturnNotFound_When_NumberIsPastTheLatest", number: 4, wantErr: estimate.ErrRevisionNotFound},
+		{name: "Should_ReturnNotFound_When_NumberIsNegative", number: -1, wantErr: estimate.ErrRevisionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, revision, err := s.Revision(context.Background(), id, tt.number)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Revision() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && revision.Number != tt.want {
+				t.Errorf("Revision() number = %d, want %d", revision.Number, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_LatestRevision(t *testing.T) {
+	s := New(paint.NewCalculateRoomPaintInCans(), estimate.NewMemoryRepository())
+	id := newEstimate(t, s, 5, 4, 3)
+
+	_, revision, err := s.LatestRevision(context.Background(), id)
+	if err != nil {
+		t.Fatalf("LatestRevision() error = %v", err)
+	}
+	if revision.Number != 3 {
+		t.Errorf("LatestRevision() number = %d, want 3", revision.Number)
+	}
+}
+
+func TestService_DiffRevisions(t *testing.T) {
+	s := New(paint.NewCalculateRoomPaintInCans(), estimate.NewMemoryRepository())
+	id := newEstimate(t, s, 5, 4, 3)
+	number := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		from, to *int
+		wantFrom int
+		wantTo   int
+		wantErr  error
+	}{
+		{name: "Should_CompareLastTwo_When_NoneIsGiven", wantFrom: 2, wantTo: 3},
+		{name: "Should_CompareWithPrevious_When_OnlyToIsGiven", to: number(2), wantFrom: 1, wantTo: 2},
+		{name: "Should_CompareWithItself_When_OnlyToIsGivenAndIsTheFirst", to: number(1), wantFrom: 1, wantTo: 1},
+		{name: "Should_CompareWithLatest_When_OnlyFromIsGiven", from: number(1), wantFrom: 1, wantTo: 3},
+		{name: "Should_ReturnNotFound_When_FromIsZero", from: number(0), wantErr: estimate.ErrRevisionNotFound},
+		{name: "Should_ReturnNotFound_When_FromIsNegative", from: number(-2), to: number(3), wantErr: estimate.ErrRevisionNotFound},
+		{name: "Should_ReturnNotFound_When_ToIsZero", to: number(0), wantErr: estimate.ErrRevisionNotFound},
+		{name: "Should_ReturnNotFound_When_ToIsPastTheLatest", to: number(4), wantErr: estimate.ErrRevisionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := s.DiffRevisions(context.Background(), id, tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DiffRevisions() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (diff.From != tt.wantFrom || diff.To != tt.wantTo) {
+				t.Errorf("DiffRevisions() = %d..%d, want %d..%d", diff.From, diff.To, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestService_UpdateEstimate_ReturnsNotFound(t *testing.T) {
+	s := New(paint.NewCalculateRoomPaintInCans(), estimate.NewMemoryRepository())
+
+	_, err := s.UpdateEstimate(context.Background(), "nao-existe", room(5))
+	if !errors.Is(err, estimate.ErrNotFound) {
+		t.Errorf("UpdateEstimate() error = %v, want %v", err, estimate.ErrNotFound)
+	}
+}
+
+func TestService_Batch(t *testing.T) {
+	s := New(paint.NewCalculateRoomPaintInCans(), estimate.NewMemoryRepository())
+
+	err := s.Batch(context.Background(), nil, func(paint.BatchResult) {
+		t.Error("Batch() emitted a result for an empty batch")
+	})
+	if entities.ValidationCode(err) != paint.CodeBatchEmpty {
+		t.Errorf("Batch() error = %v, want %s", err, paint.CodeBatchEmpty)
+	}
+
+	results := map[string]paint.BatchResult{}
+	err = s.Batch(context.Background(), []paint.BatchItem{
+		{ID: "sala", CalculateRoomPaintInCansInput: room(5)},
+		{ID: "parede-zero", CalculateRoomPaintInCansInput: room(0)},
+	}, func(result paint.BatchResult) {
+		results[result.ID] = result
+	})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if results["sala"].Result == nil || results["parede-zero"].Error == "" {
+		t.Errorf("Batch() = %+v, want a result for sala and an error for parede-zero", results)
+	}
+}
@@ -0,0 +1,354 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	invalidWorkbookError = "planilha invalida: não foi possivel encontrar a primeira aba"
+	invalidCellError     = "planilha invalida: referência de célula invalida"
+	sheetLimitError      = "planilha invalida: a planilha ultrapassa o limite de linhas ou colunas"
+	partLimitError       = "planilha invalida: o arquivo descompactado é grande demais"
+)
+
+// Limits of a sheet, the same ones enforced by spreadsheet applications.
+const (
+	maxRows    = 1048576
+	maxColumns = 16384
+)
+
+// Limits bound what ReadRows reads, so that a small crafted archive cannot
+// make it allocate without bounds. Rows counts the rows present in the sheet
+// and Columns the cells of each one; PartSize is the largest decompressed size
+// of each XML part read.
+type Limits struct {
+	Rows     int
+	Columns  int
+	PartSize int64
+}
+
+// Row is one row present in the sheet, numbered from 1 like the spreadsheet
+// application shows it.
+type Row struct {
+	Number int
+	Cells  []string
+}
+
+const (
+	workbookPath      = "xl/workbook.xml"
+	workbookRelsPath  = "xl/_rels/workbook.xml.rels"
+	sharedStringsPath = "xl/sharedStrings.xml"
+	firstSheetPath    = "xl/worksheets/sheet1.xml"
+)
+
+type workbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type richText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type sheetRow struct {
+	Index int `xml:"r,attr"`
+	Cells []struct {
+		Ref    string   `xml:"r,attr"`
+		Type   string   `xml:"t,attr"`
+		Value  string   `xml:"v"`
+		Inline richText `xml:"is"`
+	} `xml:"c"`
+}
+
+// ReadRows returns the cells of the first sheet of the workbook as text, one
+// Row per row present in the sheet. Missing cells before the last one of a
+// row come back empty so that positions match the ones shown by the
+// spreadsheet application; missing rows are left out.
+func ReadRows(r io.ReaderAt, size int64, limits Limits) ([]Row, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	sheetPath, err := findFirstSheet(archive, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every shared string is used by some cell, so there cannot be more of
+	// them than cells.
+	var shared []string
+	err = decodeElements(archive, sharedStringsPath, limits, "si", func(decoder *xml.Decoder, start xml.StartElement) error {
+		if len(shared) >= limits.Rows*limits.Columns {
+			return errors.New(sheetLimitError)
+		}
+
+		var text richText
+		err := decoder.DecodeElement(&text, &start)
+		shared = append(shared, text.String())
+		return err
+	})
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	var rows []Row
+	err = decodeElements(archive, sheetPath, limits, "row", func(decoder *xml.Decoder, start xml.StartElement) error {
+		if len(rows) >= limits.Rows {
+			return errors.New(sheetLimitError)
+		}
+
+		var row sheetRow
+		err := decoder.DecodeElement(&row, &start)
+		if err != nil {
+			return err
+		}
+
+		number := row.Index
+		if number == 0 {
+			number = 1
+			if len(rows) > 0 {
+				number = rows[len(rows)-1].Number + 1
+			}
+		}
+		if number > maxRows {
+			return errors.New(sheetLimitError)
+		}
+		if number < 1 || len(rows) > 0 && number <= rows[len(rows)-1].Number {
+			return errors.New(invalidCellError)
+		}
+
+		cells, err := readCells(row, shared, limits)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, Row{Number: number, Cells: cells})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func readCells(row sheetRow, shared []string, limits Limits) ([]string, error) {
+	cells := []string{}
+	for _, cell := range row.Cells {
+		column := len(cells)
+		if cell.Ref != "" {
+			var err error
+			column, err = columnIndex(cell.Ref)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if column >= limits.Columns || column >= maxColumns {
+			return nil, errors.New(sheetLimitError)
+		}
+		for len(cells) <= column {
+			cells = append(cells, "")
+		}
+
+		switch cell.Type {
+		case "s":
+			i, err := strconv.Atoi(cell.Value)
+			if err != nil || i < 0 || i >= len(shared) {
+				return nil, errors.New(invalidCellError)
+			}
+			cells[column] = shared[i]
+
+		case "inlineStr":
+			cells[column] = cell.Inline.String()
+
+		default:
+			cells[column] = cell.Value
+		}
+	}
+
+	return cells, nil
+}
+
+func findFirstSheet(archive *zip.Reader, limits Limits) (string, error) {
+	var book workbook
+	err := decodeFile(archive, workbookPath, limits, &book)
+	if isNotFound(err) {
+		return firstSheetPath, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(book.Sheets) == 0 {
+		return "", errors.New(invalidWorkbookError)
+	}
+
+	var rels relationships
+	err = decodeFile(archive, workbookRelsPath, limits, &rels)
+	if isNotFound(err) {
+		return firstSheetPath, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID == book.Sheets[0].ID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+
+	return "", errors.New(invalidWorkbookError)
+}
+
+var errFileNotFound = errors.New("xlsx: arquivo não encontrado")
+
+func isNotFound(err error) bool {
+	return errors.Is(err, errFileNotFound)
+}
+
+func decodeFile(archive *zip.Reader, name string, limits Limits, v interface{}) error {
+	rc, err := openFile(archive, name, limits)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// decodeElements calls decode for every element called local of the file,
+// leaving the decoding of the element to it, so that it can stop before
+// anything past a limit is allocated.
+func decodeElements(archive *zip.Reader, name string, limits Limits, local string, decode func(*xml.Decoder, xml.StartElement) error) error {
+	rc, err := openFile(archive, name, limits)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(rc)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != local {
+			continue
+		}
+		err = decode(decoder, start)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// openFile opens a file of the archive, failing once more than
+// limits.PartSize bytes are read from it, whatever size its header declares.
+func openFile(archive *zip.Reader, name string, limits Limits) (io.ReadCloser, error) {
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		if file.UncompressedSize64 > uint64(limits.PartSize) {
+			return nil, errors.New(partLimitError)
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		return &limitedReader{ReadCloser: rc, left: limits.PartSize}, nil
+	}
+
+	return nil, errFileNotFound
+}
+
+// limitedReader is an io.LimitReader that fails instead of ending the file
+// early, so the XML decoder cannot take a cut part for a whole one.
+type limitedReader struct {
+	io.ReadCloser
+	left int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if r.left <= 0 {
+		var b [1]byte
+		n, err := r.ReadCloser.Read(b[:])
+		if n > 0 {
+			return 0, errors.New(partLimitError)
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > r.left {
+		p = p[:r.left]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.left -= int64(n)
+	return n, err
+}
+
+// columnIndex converts the letters of a cell reference such as "AB12" to a
+// zero based column index.
+func columnIndex(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A') + 1
+		letters++
+	}
+	if letters == 0 || letters > 3 {
+		return 0, errors.New(invalidCellError)
+	}
+
+	return column - 1, nil
+}
+
+// ColumnName converts a zero based column index to its letters, e.g. 27 to "AB".
+func ColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,185 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testLimits = Limits{Rows: 10, Columns: 10, PartSize: 1 << 20}
+
+func newWorkbook(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadRows(t *testing.T) {
+	r := newWorkbook(t, map[string]string{
+		workbookPath: `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+			xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Paredes" sheetId="1" r:id="rId7"/></sheets></workbook>`,
+		workbookRelsPath: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId7" Target="worksheets/paredes.xml"/></Relationships>`,
+		sharedStringsPath: `<sst><si><t>room</t></si><si><r><t>sa</t></r><r><t>la</t></r></si></sst>`,
+		"xl/worksheets/paredes.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="inlineStr"><is><t>width</t></is></c></row>
+			<row r="3"><c r="A3" t="s"><v>1</v></c><c r="C3"><v>2.5</v></c></row>
+			</sheetData></worksheet>`,
+	})
+
+	got, err := ReadRows(r, r.Size(), testLimits)
+	if err != nil {
+		t.Fatalf("ReadRows() error = %v", err)
+	}
+
+	want := []Row{{Number: 1, Cells: []string{"room", "", "width"}}, {Number: 3, Cells: []string{"sala", "", "2.5"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadRows() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadRows_WithoutWorkbook(t *testing.T) {
+	r := newWorkbook(t, map[string]string{
+		firstSheetPath: `<worksheet><sheetData><row><c><v>1</v></c><c><v>2</v></c></row></sheetData></worksheet>`,
+	})
+
+	got, err := ReadRows(r, r.Size(), testLimits)
+	if err != nil {
+		t.Fatalf("ReadRows() error = %v", err)
+	}
+	if want := []Row{{Number: 1, Cells: []string{"1", "2"}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadRows() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadRows_InvalidSharedString(t *testing.T) {
+	r := newWorkbook(t, map[string]string{
+		firstSheetPath: `<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>3</v></c></row></sheetData></worksheet>`,
+	})
+
+	if _, err := ReadRows(r, r.Size(), testLimits); err == nil {
+		t.Errorf("ReadRows() error = nil, want invalid cell error")
+	}
+}
+
+func TestReadRows_Limits(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "Should_ReadSparseRow_When_IndexIsTheLastOfTheSheet",
+			files: map[string]string{firstSheetPath: `<worksheet><sheetData><row r="1048576"><c r="A1048576"><v>1</v></c></row></sheetData></worksheet>`},
+		},
+		{
+			name:  "Should_ReturnLimitError_When_RowIsPastTheSheet",
+			files: map[string]string{firstSheetPath: `<worksheet><sheetData><row r="1048577"><c><v>1</v></c></row></sheetData></worksheet>`},
+			want:  sheetLimitError,
+		},
+		{
+			name:  "Should_ReturnLimitError_When_ThereAreTooManyRows",
+			files: map[string]string{firstSheetPath: `<worksheet><sheetData>` + strings.Repeat(`<row><c><v>1</v></c></row>`, 11) + `</sheetData></worksheet>`},
+			want:  sheetLimitError,
+		},
+		{
+			name:  "Should_ReturnLimitError_When_CellIsPastTheColumns",
+			files: map[string]string{firstSheetPath: `<worksheet><sheetData><row r="1"><c r="XFD1"><v>1</v></c></row></sheetData></worksheet>`},
+			want:  sheetLimitError,
+		},
+		{
+			name: "Should_ReturnLimitError_When_ThereAreTooManySharedStrings",
+			files: map[string]string{
+				sharedStringsPath: `<sst>` + strings.Repeat(`<si><t>a</t></si>`, 101) + `</sst>`,
+				firstSheetPath:    `<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>0</v></c></row></sheetData></worksheet>`,
+			},
+			want: sheetLimitError,
+		},
+		{
+			name:  "Should_ReturnLimitError_When_PartIsTooBig",
+			files: map[string]string{firstSheetPath: `<worksheet><sheetData>` + strings.Repeat(" ", 1<<20) + `</sheetData></worksheet>`},
+			want:  partLimitError,
+		},
+		{
+			name:  "Should_ReturnInvalidCellError_When_RowsAreOutOfOrder",
+			files: map[string]string{firstSheetPath: `<worksheet><sheetData><row r="2"><c><v>1</v></c></row><row r="1"><c><v>1</v></c></row></sheetData></worksheet>`},
+			want:  invalidCellError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newWorkbook(t, tt.files)
+
+			_, err := ReadRows(r, r.Size(), testLimits)
+			if tt.want == "" && err != nil {
+				t.Fatalf("ReadRows() error = %v", err)
+			}
+			if tt.want != "" && (err == nil || err.Error() != tt.want) {
+				t.Errorf("ReadRows() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitedReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "Should_ReadAll_When_DataFitsTheLimit", data: "12345"},
+		{name: "Should_ReturnLimitError_When_DataIsPastTheLimit", data: "123456", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &limitedReader{ReadCloser: io.NopCloser(strings.NewReader(tt.data)), left: 5}
+
+			got, err := io.ReadAll(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.data {
+				t.Errorf("ReadAll() = %q, want %q", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{index: 0, want: "A"},
+		{index: 25, want: "Z"},
+		{index: 26, want: "AA"},
+		{index: 27, want: "AB"},
+		{index: 701, want: "ZZ"},
+		{index: 702, want: "AAA"},
+	}
+	for _, tt := range tests {
+		if got := ColumnName(tt.index); got != tt.want {
+			t.Errorf("ColumnName(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+		if got, _ := columnIndex(tt.want + "12"); got != tt.index {
+			t.Errorf("columnIndex(%v) = %v, want %v", tt.want, got, tt.index)
+		}
+	}
+}
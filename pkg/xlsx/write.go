@@ -0,0 +1,116 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Sheet is a worksheet to be written. Cells holding numbers (int, int64 or
+// float64) are stored as numbers; anything else is written as text.
+type Sheet struct {
+	Name string
+	Rows [][]interface{}
+}
+
+const (
+	contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`%s</Types>`
+	sheetContentTypeXML = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+
+	rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets>%s</sheets></workbook>`
+	workbookSheetXML = `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`
+
+	workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">%s</Relationships>`
+	workbookSheetRelXML = `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`
+)
+
+// WriteSheets writes a workbook with the given sheets, in order. Text is
+// stored inline so no shared strings table is needed.
+func WriteSheets(w io.Writer, sheets []Sheet) error {
+	archive := zip.NewWriter(w)
+
+	var contentTypes, workbookSheets, workbookRels strings.Builder
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, sheetContentTypeXML, n)
+		fmt.Fprintf(&workbookSheets, workbookSheetXML, escapeXML(sheet.Name), n, n)
+		fmt.Fprintf(&workbookRels, workbookSheetRelXML, n, n)
+	}
+
+	type part struct {
+		name    string
+		content string
+	}
+	parts := []part{
+		{"[Content_Types].xml", fmt.Sprintf(contentTypesXML, contentTypes.String())},
+		{"_rels/.rels", rootRelsXML},
+		{workbookPath, fmt.Sprintf(workbookXML, workbookSheets.String())},
+		{workbookRelsPath, fmt.Sprintf(workbookRelsXML, workbookRels.String())},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, part{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheetXML(sheet)})
+	}
+
+	for _, p := range parts {
+		f, err := archive.Create(p.name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(f, p.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func sheetXML(sheet Sheet) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	for i, row := range sheet.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, value := range row {
+			ref := ColumnName(j) + strconv.Itoa(i+1)
+			switch v := value.(type) {
+			case int:
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case int64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case float64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+			case nil:
+			default:
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, escapeXML(fmt.Sprint(v)))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
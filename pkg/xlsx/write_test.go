@@ -0,0 +1,28 @@
+package xlsx
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWriteSheets(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteSheets(&buf, []Sheet{
+		{Name: "Paredes", Rows: [][]interface{}{{"parede", "área"}, {1, 21.08}, {int64(2), nil, "<fim>"}}},
+		{Name: "Latas", Rows: [][]interface{}{{"lata"}}},
+	})
+	if err != nil {
+		t.Fatalf("WriteSheets() error = %v", err)
+	}
+
+	got, err := ReadRows(bytes.NewReader(buf.Bytes()), int64(buf.Len()), testLimits)
+	if err != nil {
+		t.Fatalf("ReadRows() error = %v", err)
+	}
+
+	want := []Row{{Number: 1, Cells: []string{"parede", "área"}}, {Number: 2, Cells: []string{"1", "21.08"}}, {Number: 3, Cells: []string{"2", "", "<fim>"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadRows() = %+v, want %+v", got, want)
+	}
+}
@@ -1,69 +1,232 @@
 package server
 
 import (
+	"context"
+	"digitalrepublic/api/handlers"
 	"digitalrepublic/api/routes"
+	"digitalrepublic/pkg/apikey"
+	"digitalrepublic/pkg/catalog"
+	"digitalrepublic/pkg/config"
+	"digitalrepublic/pkg/estimate"
+	"digitalrepublic/pkg/metrics"
+	"digitalrepublic/pkg/paint"
+	"digitalrepublic/pkg/service"
+	"errors"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
-	"os"
-	"os/signal"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"log/slog"
+	"strings"
+	"sync/atomic"
 )
 
+// Server runs the API. Start blocks until the server stops, returning why it
+// could not listen; Stop drains in-flight requests until ctx is done.
 type Server interface {
-	Start()
+	Start() error
+	Stop(ctx context.Context) error
 }
 
+// probePaths are the orchestrator and monitoring endpoints, which are never
+// rate limited.
+var probePaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/version": true,
+	"/metrics": true,
+}
+
+const apiPrefix = "/api/v1"
+
+const shuttingDownError = "server is shutting down"
+
 type server struct {
-	Fiber *fiber.App
+	accepting  atomic.Bool
+	base       context.Context
+	cancel     context.CancelFunc
+	Fiber      *fiber.App
+	Config     config.Config
+	Estimates  estimate.Repository
+	Keys       apikey.Store
+	Catalog    catalog.Catalog
+	Metrics    *metrics.Metrics
+	Interactor paint.CalculateRoomPaintInCans
+	Services   *service.Service
+	Logger     *slog.Logger
+}
+
+// Option replaces a dependency New would otherwise build from the config.
+type Option func(*server)
+
+func WithEstimates(estimates estimate.Repository) Option {
+	return func(e *server) {
+		e.Estimates = estimates
+	}
+}
+
+func WithKeys(keys apikey.Store) Option {
+	return func(e *server) {
+		e.Keys = keys
+	}
+}
+
+func WithCatalog(prices catalog.Catalog) Option {
+	return func(e *server) {
+		e.Catalog = prices
+	}
 }
 
-func New() Server {
-	return &server{}
+// WithInteractor sets the interactor behind every transport. It is still
+// instrumented by the server.
+func WithInteractor(interactor paint.CalculateRoomPaintInCans) Option {
+	return func(e *server) {
+		e.Interactor = interactor
+	}
 }
 
-func (e *server) Start() {
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+// New builds the server and its dependencies once; the handlers share them
+// across requests.
+func New(cfg config.Config, logger *slog.Logger, options ...Option) (Server, error) {
+	e := &server{
+		Config:  cfg,
+		Catalog: catalog.Default(),
+		Metrics: metrics.New(),
+		Logger:  logger,
+	}
+	for _, option := range options {
+		option(e)
+	}
+
+	if e.Estimates == nil {
+		e.Estimates = estimate.NewMemoryRepository()
+		if cfg.EstimatesFile != "" {
+			var err error
+			e.Estimates, err = estimate.NewFileRepository(cfg.EstimatesFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if e.Keys == nil {
+		e.Keys = apikey.NewMemoryStore()
+		if cfg.APIKeysFile != "" {
+			var err error
+			e.Keys, err = apikey.NewFileStore(cfg.APIKeysFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if e.Interactor == nil {
+		e.Interactor = paint.NewCalculateRoomPaintInCans(paint.WithLogger(logger))
+	}
+	e.Services = service.New(e.Metrics.Instrument(e.Interactor), e.Estimates)
+
+	e.base, e.cancel = context.WithCancel(context.Background())
+	e.setup()
+	e.accepting.Store(true)
+
+	return e, nil
+}
+
+func (e *server) Start() error {
+	e.Logger.Info("server listening", "address", e.Config.Address, "app", e.Config.AppName)
+	return e.Fiber.Listen(e.Config.Address)
+}
+
+func (e *server) Stop(ctx context.Context) error {
+	e.accepting.Store(false)
+	e.Logger.Info("draining in-flight requests")
+
+	done := make(chan error, 1)
 	go func() {
-		_ = <-c
-		fmt.Println("Gracefully shutting down...")
-		_ = e.Fiber.Shutdown()
+		done <- e.Fiber.Shutdown()
 	}()
 
+	// Requests still running once ctx is done, such as long batches, are
+	// canceled rather than left to finish.
+	select {
+	case err := <-done:
+		e.cancel()
+		return err
+	case <-ctx.Done():
+		e.cancel()
+		return ctx.Err()
+	}
+}
+
+func (e *server) setup() {
 	e.Fiber = fiber.New(fiber.Config{
-		AppName:      "Paint Calculator!",
-		ServerHeader: "Fiber",
+		AppName:               e.Config.AppName,
+		ServerHeader:          "Fiber",
+		DisableStartupMessage: true,
+		BodyLimit:             e.Config.BodyLimit,
+		ReadTimeout:           e.Config.ReadTimeout,
+		WriteTimeout:          e.Config.WriteTimeout,
+		IdleTimeout:           e.Config.IdleTimeout,
+		ErrorHandler:          handlers.ErrorHandler,
 	})
 
 	// Use global middlewares.
-	e.Fiber.Use(cors.New())
+	e.Fiber.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(e.base)
+		return c.Next()
+	})
+	e.Fiber.Use(handlers.RequestLogger(e.Logger))
+	e.Fiber.Use(handlers.RecordRequests(e.Metrics))
+	e.Fiber.Use(recover.New())
+	e.Fiber.Use(cors.New(cors.Config{
+		AllowOrigins: strings.Join(e.Config.CORSOrigins, ","),
+	}))
 	e.Fiber.Use(limiter.New(limiter.Config{
-		Max: 100,
+		Next: func(c *fiber.Ctx) bool {
+			// With API keys each key has its own limits instead.
+			return probePaths[c.Path()] || (e.Config.RequireAPIKey && strings.HasPrefix(c.Path(), apiPrefix))
+		},
+		Max:        e.Config.RateLimit,
+		Expiration: e.Config.RateLimitWindow,
 		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(&fiber.Map{
-				"status":  "fail",
-				"message": "You have requested too many in a single time-frame! Please wait another minute!",
-			})
+			return handlers.NewProblem(fiber.StatusTooManyRequests, "You have requested too many in a single time-frame! Please wait another minute!")
 		},
 	}))
 
-	e.Fiber.Get("/", func(ctx *fiber.Ctx) error {
-		return ctx.Send([]byte("Welcome to Paint Calculator!"))
-	})
-	api := e.Fiber.Group("/api/v1")
-	routes.Router(api)
+	e.Fiber.Get("/", handlers.Home())
+	e.Fiber.Get("/healthz", handlers.Healthz())
+	e.Fiber.Get("/readyz", handlers.Readyz(e.ready))
+	e.Fiber.Get("/version", handlers.Version())
+	e.Fiber.Get("/metrics", handlers.Metrics(e.Metrics))
+	e.Fiber.Get("/openapi.json", handlers.OpenAPI())
+	e.Fiber.Get("/docs", handlers.Docs())
+
+	api := e.Fiber.Group(apiPrefix)
+	if e.Config.RequireAPIKey {
+		api.Use(handlers.RequireAPIKey(e.Keys, apikey.NewLimiter(e.Config.RateLimitWindow)))
+	}
+	routes.Router(api, e.Services, e.Catalog)
+
+	if e.Config.AdminToken != "" {
+		admin := e.Fiber.Group("/admin", handlers.RequireAdminToken(e.Config.AdminToken))
+		routes.Admin(admin, e.Keys, e.Config.RateLimit)
+	}
 
 	// Prepare an endpoint for 'Not Found'.
 	e.Fiber.All("*", func(c *fiber.Ctx) error {
 		errorMessage := fmt.Sprintf("Route '%s' does not exist in this API!", c.OriginalURL())
 
-		return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
-			"status":  "fail",
-			"message": errorMessage,
-		})
+		return handlers.NewProblem(fiber.StatusNotFound, errorMessage)
 	})
+}
+
+// ready reports whether the server should receive traffic: it is not shutting
+// down and the estimates storage answers.
+func (e *server) ready() error {
+	if !e.accepting.Load() {
+		return errors.New(shuttingDownError)
+	}
 
-	// Listen to port 8080.
-	e.Fiber.Listen(":8080")
+	return e.Estimates.Ping()
 }